@@ -6,6 +6,7 @@ import (
 )
 
 // ErrorAttr returns an attribute with the error.
+// See [ErrorDetailsAttr] for an attribute with more details about the error.
 func ErrorAttr(err error) slog.Attr {
 	return slog.Any(ErrorKey, err)
 }
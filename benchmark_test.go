@@ -52,6 +52,25 @@ func benchmarkSLogXLogger(b *testing.B, longTerm bool) {
 		})
 	}
 
+	testEvent := func(b *testing.B, logger *slogx.Logger) {
+		b.Helper()
+
+		b.Run("Event", func(b *testing.B) {
+			b.Run("NoAttrs", func(b *testing.B) {
+				b.ResetTimer()
+				for i := 0; i != b.N; i++ {
+					logger.InfoEvent().Msg("msg")
+				}
+			})
+			b.Run("ThreeAttrs", func(b *testing.B) {
+				b.ResetTimer()
+				for i := 0; i != b.N; i++ {
+					logger.InfoEvent().Str("a", "av").Str("b", "bv").Str("c", "cv").Msg("msg")
+				}
+			})
+		})
+	}
+
 	testWith := func(b *testing.B, logger *slogx.Logger) {
 		b.Helper()
 
@@ -207,6 +226,7 @@ func benchmarkSLogXLogger(b *testing.B, longTerm bool) {
 
 		testEnabled(b, logger)
 		testLogAttrs(b, logger)
+		testEvent(b, logger)
 		if longTerm {
 			testWithLongTerm(b, logger)
 			testWithAndLogLongTerm(b, logger)
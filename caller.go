@@ -0,0 +1,113 @@
+package slogx
+
+import (
+	"maps"
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// Helper marks the calling function as a logging helper function.
+// When computing the source location of a log record, frames of helper functions are skipped,
+// so the location of the code calling the helper is reported instead.
+// It works similarly to [testing.T.Helper], but the marking is global and applies to all loggers.
+func Helper() {
+	var pcs [1]uintptr
+	if runtime.Callers(2, pcs[:]) == 0 {
+		return
+	}
+
+	if helpers.enabled.Load() && helpers.lookup(pcs[0]) {
+		return
+	}
+
+	helpers.mu.Lock()
+	defer helpers.mu.Unlock()
+
+	name := functionName(pcs[0])
+	if _, ok := helpers.names[name]; ok {
+		return
+	}
+
+	if helpers.names == nil {
+		helpers.names = make(map[string]struct{})
+	}
+
+	helpers.names[name] = struct{}{}
+	helpers.pcs.Store(&map[uintptr]bool{pcs[0]: true})
+	helpers.enabled.Store(true)
+}
+
+// ---
+
+// callerPC returns the program counter of the caller skipping the specified amount of frames
+// and any frames belonging to the functions marked using [Helper].
+// The skip argument has the same meaning as in [runtime.Callers] called by callerPC's caller.
+func callerPC(skip int) uintptr {
+	if !helpers.enabled.Load() {
+		var pcs [1]uintptr
+		runtime.Callers(skip+1, pcs[:])
+
+		return pcs[0]
+	}
+
+	var pcs [maxHelperDepth]uintptr
+
+	n := runtime.Callers(skip+1, pcs[:])
+	for _, pc := range pcs[:n] {
+		if !helpers.lookup(pc) {
+			return pc
+		}
+	}
+
+	if n != 0 {
+		return pcs[n-1]
+	}
+
+	return 0
+}
+
+func functionName(pc uintptr) string {
+	if fn := runtime.FuncForPC(pc - 1); fn != nil {
+		return fn.Name()
+	}
+
+	return ""
+}
+
+// ---
+
+var helpers helperRegistry
+
+const maxHelperDepth = 16
+
+// ---
+
+// helperRegistry contains the names of the functions marked using [Helper]
+// and caches whether a program counter belongs to any of them.
+// The cache is a copy-on-write map, so the lookups of known program counters do not take any locks.
+type helperRegistry struct {
+	mu      sync.Mutex
+	names   map[string]struct{}
+	pcs     atomic.Pointer[map[uintptr]bool]
+	enabled atomic.Bool
+}
+
+// lookup reports whether the program counter belongs to a helper function.
+// It must be called only after the first helper is registered.
+func (r *helperRegistry) lookup(pc uintptr) bool {
+	if helper, ok := (*r.pcs.Load())[pc]; ok {
+		return helper
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	_, helper := r.names[functionName(pc)]
+
+	pcs := maps.Clone(*r.pcs.Load())
+	pcs[pc] = helper
+	r.pcs.Store(&pcs)
+
+	return helper
+}
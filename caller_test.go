@@ -0,0 +1,106 @@
+package slogx_test
+
+import (
+	"context"
+	"log/slog"
+	"runtime"
+	"testing"
+
+	. "github.com/pamburus/go-tst/tst"
+	"github.com/pamburus/slogx"
+	"github.com/pamburus/slogx/internal/mock"
+)
+
+func TestCaller(tt *testing.T) {
+	t := New(tt)
+	ctx := context.Background()
+
+	setup := func() (*mock.CallLog, *slogx.Logger) {
+		cl := mock.NewCallLog()
+
+		return cl, slogx.New(mock.NewHandler(cl))
+	}
+
+	lastRecord := func(cl *mock.CallLog) mock.Record {
+		calls := cl.Calls().WithoutTime()
+
+		return calls[len(calls)-1].(mock.HandlerHandle).Record
+	}
+
+	t.Run("CallerSkip", func(t Test) {
+		cl, logger := setup()
+
+		callLogVia(logger)
+		t.Expect(functionOf(lastRecord(cl).PC)).To(Equal("github.com/pamburus/slogx_test.callLogVia"))
+
+		callLogVia(logger.ContextLogger())
+		t.Expect(functionOf(lastRecord(cl).PC)).To(Equal("github.com/pamburus/slogx_test.callLogVia"))
+	})
+
+	t.Run("PC", func(t Test) {
+		cl, logger := setup()
+
+		var pcs [1]uintptr
+		runtime.Callers(1, pcs[:])
+
+		logger.LogWithPC(ctx, pcs[0], slog.LevelInfo, "m1")
+		t.Expect(lastRecord(cl).PC).To(Equal(pcs[0]))
+
+		logger.ContextLogger().LogWithPC(ctx, pcs[0], slog.LevelInfo, "m2")
+		t.Expect(lastRecord(cl).PC).To(Equal(pcs[0]))
+
+		logger.WithSource(false).LogWithPC(ctx, pcs[0], slog.LevelInfo, "m3")
+		t.Expect(lastRecord(cl).PC).To(Equal(uintptr(0)))
+	})
+
+	t.Run("Helper", func(t Test) {
+		cl, logger := setup()
+
+		callOuterHelper(logger)
+		t.Expect(functionOf(lastRecord(cl).PC)).To(Equal("github.com/pamburus/slogx_test.callOuterHelper"))
+
+		logDirectly(logger)
+		t.Expect(functionOf(lastRecord(cl).PC)).To(Equal("github.com/pamburus/slogx_test.logDirectly"))
+
+		callOuterHelper(logger)
+		t.Expect(functionOf(lastRecord(cl).PC)).To(Equal("github.com/pamburus/slogx_test.callOuterHelper"))
+	})
+}
+
+// ---
+
+type callerSkipLogger interface {
+	LogWithCallerSkip(ctx context.Context, skip int, level slog.Level, msg string, attrs ...slog.Attr)
+}
+
+//go:noinline
+func callLogVia(logger callerSkipLogger) {
+	logVia(logger)
+}
+
+//go:noinline
+func logVia(logger callerSkipLogger) {
+	logger.LogWithCallerSkip(context.Background(), 1, slog.LevelInfo, "m1")
+}
+
+//go:noinline
+func callOuterHelper(logger *slogx.Logger) {
+	outerHelper(logger)
+}
+
+//go:noinline
+func logDirectly(logger *slogx.Logger) {
+	logger.Info("m2")
+}
+
+//go:noinline
+func outerHelper(logger *slogx.Logger) {
+	slogx.Helper()
+	innerHelper(logger)
+}
+
+//go:noinline
+func innerHelper(logger *slogx.Logger) {
+	slogx.Helper()
+	logger.Info("m1")
+}
@@ -0,0 +1,184 @@
+package slogx
+
+import (
+	"cmp"
+	"context"
+	"log/slog"
+)
+
+// DebugFunc logs a message at the debug level.
+// The message and attributes are built by calling fn only if the level is enabled.
+func (l *Logger) DebugFunc(fn func() (string, []slog.Attr)) {
+	l.logFunc(context.Background(), slog.LevelDebug, fn, 0)
+}
+
+// InfoFunc logs a message at the info level.
+// The message and attributes are built by calling fn only if the level is enabled.
+func (l *Logger) InfoFunc(fn func() (string, []slog.Attr)) {
+	l.logFunc(context.Background(), slog.LevelInfo, fn, 0)
+}
+
+// WarnFunc logs a message at the warn level.
+// The message and attributes are built by calling fn only if the level is enabled.
+func (l *Logger) WarnFunc(fn func() (string, []slog.Attr)) {
+	l.logFunc(context.Background(), slog.LevelWarn, fn, 0)
+}
+
+// ErrorFunc logs a message at the error level.
+// The message and attributes are built by calling fn only if the level is enabled.
+func (l *Logger) ErrorFunc(fn func() (string, []slog.Attr)) {
+	l.logFunc(context.Background(), slog.LevelError, fn, 0)
+}
+
+// LogFunc logs a message at the given level.
+// The message and attributes are built by calling fn only if the level is enabled.
+func (l *Logger) LogFunc(level slog.Level, fn func() (string, []slog.Attr)) {
+	l.logFunc(context.Background(), level, fn, 0)
+}
+
+// LogFuncContext logs a message at the given level with the given context.
+// The message and attributes are built by calling fn only if the level is enabled.
+func (l *Logger) LogFuncContext(ctx context.Context, level slog.Level, fn func() (string, []slog.Attr)) {
+	l.logFunc(ctx, level, fn, 0)
+}
+
+// If returns a [LevelLogger] logging at the given level if the level is enabled, or nil otherwise,
+// which allows to skip building the message and attributes when the level is disabled, for example:
+//
+//	if e := logger.If(slog.LevelDebug); e != nil {
+//		e.Log("message", slog.Any("state", expensiveState()))
+//	}
+//
+// Nothing is allocated if the level is disabled.
+func (l *Logger) If(level slog.Level) *LevelLogger {
+	if !l.handler.Enabled(context.Background(), level) {
+		return nil
+	}
+
+	return &LevelLogger{&l.commonLogger, level}
+}
+
+// ---
+
+// DebugFunc logs a message at the debug level.
+// The message and attributes are built by calling fn only if the level is enabled.
+func (l *ContextLogger) DebugFunc(ctx context.Context, fn func() (string, []slog.Attr)) {
+	l.logFunc(ctx, slog.LevelDebug, fn, 0)
+}
+
+// InfoFunc logs a message at the info level.
+// The message and attributes are built by calling fn only if the level is enabled.
+func (l *ContextLogger) InfoFunc(ctx context.Context, fn func() (string, []slog.Attr)) {
+	l.logFunc(ctx, slog.LevelInfo, fn, 0)
+}
+
+// WarnFunc logs a message at the warn level.
+// The message and attributes are built by calling fn only if the level is enabled.
+func (l *ContextLogger) WarnFunc(ctx context.Context, fn func() (string, []slog.Attr)) {
+	l.logFunc(ctx, slog.LevelWarn, fn, 0)
+}
+
+// ErrorFunc logs a message at the error level.
+// The message and attributes are built by calling fn only if the level is enabled.
+func (l *ContextLogger) ErrorFunc(ctx context.Context, fn func() (string, []slog.Attr)) {
+	l.logFunc(ctx, slog.LevelError, fn, 0)
+}
+
+// LogFunc logs a message at the given level.
+// The message and attributes are built by calling fn only if the level is enabled.
+func (l *ContextLogger) LogFunc(ctx context.Context, level slog.Level, fn func() (string, []slog.Attr)) {
+	l.logFunc(ctx, level, fn, 0)
+}
+
+// If returns a [ContextLevelLogger] logging at the given level if the level is enabled for the context, or nil otherwise,
+// which allows to skip building the message and attributes when the level is disabled, for example:
+//
+//	if e := logger.If(ctx, slog.LevelDebug); e != nil {
+//		e.Log(ctx, "message", slog.Any("state", expensiveState()))
+//	}
+//
+// Nothing is allocated if the level is disabled.
+func (l *ContextLogger) If(ctx context.Context, level slog.Level) *ContextLevelLogger {
+	if !l.handler.Enabled(cmp.Or(ctx, context.Background()), level) {
+		return nil
+	}
+
+	return &ContextLevelLogger{&l.commonLogger, level}
+}
+
+// ---
+
+// LevelLogger logs messages at a fixed level that is known to be enabled.
+// It is returned by [Logger.If].
+// All methods of a nil LevelLogger do nothing.
+type LevelLogger struct {
+	logger *commonLogger
+	level  slog.Level
+}
+
+// Level returns the level of the logger.
+func (l *LevelLogger) Level() slog.Level {
+	if l == nil {
+		return 0
+	}
+
+	return l.level
+}
+
+// Log logs a message at the logger's level.
+func (l *LevelLogger) Log(msg string, attrs ...slog.Attr) {
+	if l != nil {
+		l.logger.logEnabled(context.Background(), l.level, msg, attrs, 0)
+	}
+}
+
+// LogContext logs a message at the logger's level with the given context.
+func (l *LevelLogger) LogContext(ctx context.Context, msg string, attrs ...slog.Attr) {
+	if l != nil {
+		l.logger.logEnabled(cmp.Or(ctx, context.Background()), l.level, msg, attrs, 0)
+	}
+}
+
+// ---
+
+// ContextLevelLogger logs messages at a fixed level that is known to be enabled.
+// It is returned by [ContextLogger.If].
+// All methods of a nil ContextLevelLogger do nothing.
+type ContextLevelLogger struct {
+	logger *commonLogger
+	level  slog.Level
+}
+
+// Level returns the level of the logger.
+func (l *ContextLevelLogger) Level() slog.Level {
+	if l == nil {
+		return 0
+	}
+
+	return l.level
+}
+
+// Log logs a message at the logger's level.
+func (l *ContextLevelLogger) Log(ctx context.Context, msg string, attrs ...slog.Attr) {
+	if l != nil {
+		l.logger.logEnabled(cmp.Or(ctx, context.Background()), l.level, msg, attrs, 0)
+	}
+}
+
+// ---
+
+func (l *commonLogger) logFunc(ctx context.Context, level slog.Level, fn func() (string, []slog.Attr), skip int) {
+	ctx = cmp.Or(ctx, context.Background())
+
+	if !l.handler.Enabled(ctx, level) {
+		return
+	}
+
+	var pc uintptr
+	if l.sourceEnabled(level) {
+		pc = callerPC(skip + 3)
+	}
+
+	msg, attrs := fn()
+	l.handle(ctx, level, msg, attrs, pc)
+}
@@ -0,0 +1,137 @@
+package slogx_test
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+
+	. "github.com/pamburus/go-tst/tst"
+	"github.com/pamburus/slogx"
+	"github.com/pamburus/slogx/internal/mock"
+)
+
+func TestConditional(tt *testing.T) {
+	t := New(tt)
+	ctx := context.Background()
+
+	setup := func() (*mock.CallLog, *slogx.Logger) {
+		cl := mock.NewCallLog()
+		handler := slogx.TweakHandler(mock.NewHandler(cl)).WithLevel(slog.LevelInfo).Result()
+
+		return cl, slogx.New(handler)
+	}
+
+	records := func(cl *mock.CallLog) []mock.Record {
+		var result []mock.Record
+
+		for _, call := range cl.Calls().WithoutTime() {
+			if call, ok := call.(mock.HandlerHandle); ok {
+				result = append(result, call.Record)
+			}
+		}
+
+		return result
+	}
+
+	t.Run("Func", func(t Test) {
+		cl, logger := setup()
+		calls := 0
+
+		fn := func(msg string) func() (string, []slog.Attr) {
+			return func() (string, []slog.Attr) {
+				calls++
+
+				return msg, []slog.Attr{slog.Int("a", calls)}
+			}
+		}
+
+		logFuncs(logger, fn)
+		logger.WithSource(false).LogFunc(slog.LevelError, fn("m5"))
+		logger.WithSource(false).LogFuncContext(ctx, slog.LevelDebug, fn("m6"))
+		logger.WithSource(false).LogFuncContext(ctx, slog.LevelError, fn("m7"))
+
+		t.Expect(calls).To(Equal(4))
+
+		result := records(cl)
+		t.Expect(result).To(HaveLen(4))
+		t.Expect(functionOf(result[0].PC)).To(Equal("github.com/pamburus/slogx_test.logFuncs"))
+		t.Expect(functionOf(result[1].PC)).To(Equal("github.com/pamburus/slogx_test.logFuncs"))
+
+		for i, r := range result {
+			r.PC = 0
+			result[i] = r
+		}
+
+		t.Expect(result).To(Equal([]mock.Record{
+			{Level: slog.LevelInfo, Message: "m2", Attrs: []mock.Attr{{Key: "a", Value: int64(1)}}},
+			{Level: slog.LevelWarn, Message: "m4", Attrs: []mock.Attr{{Key: "a", Value: int64(2)}}},
+			{Level: slog.LevelError, Message: "m5", Attrs: []mock.Attr{{Key: "a", Value: int64(3)}}},
+			{Level: slog.LevelError, Message: "m7", Attrs: []mock.Attr{{Key: "a", Value: int64(4)}}},
+		}))
+	})
+
+	t.Run("If", func(t Test) {
+		cl, logger := setup()
+		logger = logger.WithSource(false)
+
+		t.Expect(logger.If(slog.LevelDebug) == nil).To(BeTrue())
+		t.Expect(logger.ContextLogger().If(ctx, slog.LevelDebug) == nil).To(BeTrue())
+
+		logger.If(slog.LevelDebug).Log("m1")
+		logger.ContextLogger().If(ctx, slog.LevelDebug).Log(ctx, "m2")
+
+		if e := logger.If(slog.LevelWarn); e != nil {
+			t.Expect(e.Level()).To(Equal(slog.LevelWarn))
+			e.Log("m3", slog.Int("a", 1))
+			e.LogContext(ctx, "m4")
+		}
+
+		if e := logger.ContextLogger().If(ctx, slog.LevelInfo); e != nil {
+			e.Log(ctx, "m5")
+		}
+
+		t.Expect(records(cl)).To(Equal([]mock.Record{
+			{Level: slog.LevelWarn, Message: "m3", Attrs: []mock.Attr{{Key: "a", Value: int64(1)}}},
+			{Level: slog.LevelWarn, Message: "m4"},
+			{Level: slog.LevelInfo, Message: "m5"},
+		}))
+	})
+
+	t.Run("IfSource", func(t Test) {
+		cl, logger := setup()
+
+		logIf(logger)
+
+		result := records(cl)
+		t.Expect(result).To(HaveLen(2))
+		t.Expect(functionOf(result[0].PC)).To(Equal("github.com/pamburus/slogx_test.logIf"))
+		t.Expect(functionOf(result[1].PC)).To(Equal("github.com/pamburus/slogx_test.logIf"))
+	})
+
+	t.Run("IfAllocs", func(t Test) {
+		_, logger := setup()
+		contextLogger := logger.ContextLogger()
+
+		allocs := testing.AllocsPerRun(100, func() {
+			logger.If(slog.LevelDebug).Log("m1")
+			contextLogger.If(ctx, slog.LevelDebug).Log(ctx, "m2")
+		})
+		t.Expect(allocs).To(BeZero())
+	})
+}
+
+// ---
+
+//go:noinline
+func logFuncs(logger *slogx.Logger, fn func(string) func() (string, []slog.Attr)) {
+	logger.DebugFunc(fn("m1"))
+	logger.InfoFunc(fn("m2"))
+	logger.ContextLogger().DebugFunc(context.Background(), fn("m3"))
+	logger.ContextLogger().WarnFunc(context.Background(), fn("m4"))
+}
+
+//go:noinline
+func logIf(logger *slogx.Logger) {
+	logger.If(slog.LevelInfo).Log("m1")
+	logger.ContextLogger().If(context.Background(), slog.LevelWarn).Log(context.Background(), "m2")
+}
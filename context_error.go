@@ -0,0 +1,163 @@
+package slogx
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+
+	"github.com/pamburus/slogx/internal/ctxkey"
+)
+
+// WrapError returns an error wrapping err that captures the attributes of the logger stored in the context
+// using [github.com/pamburus/slogx/slogc.New] along with the given attributes.
+// When the returned error is logged later as an attribute value, for example using [ErrorAttr],
+// by a handler built using [TweakHandlerBuilder.WithErrorContext], the captured attributes are added to the record,
+// except those the logging site already has, so request-scoped attributes are not lost
+// even if the error is logged far up the stack.
+// Other handlers log the error as is.
+// Attributes and groups added to the handler using [slog.Handler.WithAttrs] and [slog.Handler.WithGroup]
+// are captured only if the handler of the logger stored in the context is the one built by
+// [TweakHandlerBuilder.WithErrorContext] and is not wrapped by another handler, for example by [Deduplicate]
+// or [SwapHandler]; otherwise only the attributes of the logger itself and the given attributes are captured.
+// The returned error has the same message as err and unwraps to err.
+// If err is nil, WrapError returns nil.
+func WrapError(ctx context.Context, err error, attrs ...slog.Attr) error {
+	if err == nil {
+		return nil
+	}
+
+	result := &contextError{err: err}
+
+	if ctx != nil {
+		if logger, ok := ctx.Value(&ctxkey.Logger).(*ContextLogger); ok {
+			if handler, ok := logger.handler.(*tweakedHandler); ok {
+				result.scope = handler.scope
+			}
+			result.attrs = logger.attrs.Collect()
+		}
+	}
+
+	result.attrs = append(result.attrs, attrs...)
+
+	return result
+}
+
+// ---
+
+type contextError struct {
+	err   error
+	scope *attrScope
+	attrs []slog.Attr
+}
+
+func (e *contextError) Error() string {
+	return e.err.Error()
+}
+
+func (e *contextError) Unwrap() error {
+	return e.err
+}
+
+// attrsFor returns the captured attributes except those that are already present in the given scope.
+func (e *contextError) attrsFor(scope *attrScope) []slog.Attr {
+	result := e.attrs
+
+	for s := e.scope; s != nil && !scope.contains(s); s = s.parent {
+		if s.group != "" {
+			if len(result) != 0 {
+				result = []slog.Attr{{Key: s.group, Value: slog.GroupValue(result...)}}
+			}
+		} else {
+			result = append(s.attrs[:len(s.attrs):len(s.attrs)], result...)
+		}
+	}
+
+	return result
+}
+
+// ---
+
+func errorOf(value slog.Value) error {
+	switch value.Kind() {
+	case slog.KindAny:
+		err, _ := value.Any().(error)
+
+		return err
+	case slog.KindLogValuer:
+		if details, ok := value.Any().(errorDetails); ok {
+			return details.err
+		}
+	}
+
+	return nil
+}
+
+// errorContextAttrs returns the attributes captured by the errors returned by [WrapError]
+// found in the record attributes, except those that are already present in the record or in the given scope.
+func errorContextAttrs(record slog.Record, scope *attrScope) []slog.Attr {
+	var result []slog.Attr
+
+	record.Attrs(func(attr slog.Attr) bool {
+		walkContextErrors(errorOf(attr.Value), func(ce *contextError) {
+			result = append(result, ce.attrsFor(scope)...)
+		})
+
+		return true
+	})
+
+	if len(result) == 0 {
+		return nil
+	}
+
+	record.Attrs(func(attr slog.Attr) bool {
+		result = slices.DeleteFunc(result, func(a slog.Attr) bool {
+			return a.Key == attr.Key
+		})
+
+		return len(result) != 0
+	})
+
+	return result
+}
+
+// walkContextErrors calls fn for each error returned by [WrapError] in the tree of err,
+// following all branches of errors wrapping multiple errors, such as those returned by [errors.Join].
+func walkContextErrors(err error, fn func(*contextError)) {
+	for err != nil {
+		switch e := err.(type) {
+		case *contextError:
+			fn(e)
+			err = e.err
+		case interface{ Unwrap() error }:
+			err = e.Unwrap()
+		case interface{ Unwrap() []error }:
+			for _, err := range e.Unwrap() {
+				walkContextErrors(err, fn)
+			}
+
+			return
+		default:
+			return
+		}
+	}
+}
+
+// ---
+
+// attrScope is a node in the chain of attributes and groups added to a handler
+// using [slog.Handler.WithAttrs] and [slog.Handler.WithGroup].
+type attrScope struct {
+	parent *attrScope
+	group  string
+	attrs  []slog.Attr
+}
+
+func (s *attrScope) contains(other *attrScope) bool {
+	for ; s != nil; s = s.parent {
+		if s == other {
+			return true
+		}
+	}
+
+	return false
+}
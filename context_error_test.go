@@ -0,0 +1,110 @@
+package slogx_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"testing"
+
+	. "github.com/pamburus/go-tst/tst"
+	"github.com/pamburus/slogx"
+	"github.com/pamburus/slogx/internal/mock"
+	"github.com/pamburus/slogx/slogc"
+)
+
+func TestWrapError(tt *testing.T) {
+	t := New(tt)
+
+	setup := func() (*mock.CallLog, *slogx.ContextLogger) {
+		cl := mock.NewCallLog()
+
+		handler := slogx.TweakHandler(mock.NewHandler(cl)).WithErrorContext().Result()
+
+		return cl, slogx.NewContextLogger(handler).WithSource(false)
+	}
+
+	lastRecord := func(cl *mock.CallLog) mock.Record {
+		calls := cl.Calls().WithoutTime()
+
+		return calls[len(calls)-1].(mock.HandlerHandle).Record
+	}
+
+	t.Run("Nil", func(t Test) {
+		t.Expect(slogx.WrapError(context.Background(), nil)).To(BeNil())
+	})
+
+	t.Run("Transparent", func(t Test) {
+		cause := errors.New("e1")
+		err := slogx.WrapError(context.Background(), cause, slog.String("a", "v"))
+
+		t.Expect(err.Error()).To(Equal("e1"))
+		t.Expect(errors.Is(err, cause)).To(BeTrue())
+	})
+
+	t.Run("Captured", func(t Test) {
+		cl, logger := setup()
+
+		ctx := slogc.New(context.Background(), logger)
+		ctx = slogc.With(ctx, slog.String("request", "r1"))
+		ctx = slogc.WithGroup(ctx, "g1")
+		ctx = slogc.New(ctx, slogc.Get(ctx).With(slog.String("user", "u1")))
+
+		err := fmt.Errorf("e2: %w", slogx.WrapError(ctx, errors.New("e1"), slog.Int("n", 1)))
+		logger.Error(context.Background(), "failed", slogx.ErrorAttr(err))
+
+		t.Expect(lastRecord(cl).Attrs).To(Equal([]mock.Attr{
+			{Key: slogx.ErrorKey, Value: err},
+			{Key: "request", Value: "r1"},
+			{Key: "g1", Value: []slog.Attr{slog.String("user", "u1"), slog.Int("n", 1)}},
+		}))
+	})
+
+	t.Run("Deduplicated", func(t Test) {
+		cl, logger := setup()
+
+		ctx := slogc.New(context.Background(), logger)
+		ctx = slogc.With(ctx, slog.String("request", "r1"))
+		inner := slogc.With(ctx, slog.String("op", "o1"))
+
+		err := slogx.WrapError(inner, errors.New("e1"), slog.String("user", "u1"))
+		slogc.Get(ctx).With(slog.String("user", "u2")).Error(ctx, "failed", slogx.ErrorDetailsAttr(err))
+
+		attrs := lastRecord(cl).Attrs
+		t.Expect(attrs).To(HaveLen(3))
+		t.Expect(attrs[0]).To(Equal(mock.Attr{Key: "user", Value: "u2"}))
+		t.Expect(attrs[1].Key).To(Equal(slogx.ErrorKey))
+		t.Expect(attrs[2]).To(Equal(mock.Attr{Key: "op", Value: "o1"}))
+	})
+
+	t.Run("Joined", func(t Test) {
+		cl, logger := setup()
+
+		ctx := slogc.New(context.Background(), logger)
+		err := errors.Join(
+			errors.New("e1"),
+			slogx.WrapError(ctx, errors.New("e2"), slog.Int("n", 2)),
+			fmt.Errorf("e3: %w", slogx.WrapError(ctx, errors.New("e3"), slog.Int("m", 3))),
+		)
+		logger.Error(context.Background(), "failed", slogx.ErrorAttr(err))
+
+		t.Expect(lastRecord(cl).Attrs).To(Equal([]mock.Attr{
+			{Key: slogx.ErrorKey, Value: err},
+			{Key: "n", Value: int64(2)},
+			{Key: "m", Value: int64(3)},
+		}))
+	})
+
+	t.Run("Disabled", func(t Test) {
+		cl := mock.NewCallLog()
+		logger := slogx.NewContextLogger(mock.NewHandler(cl)).WithSource(false)
+
+		ctx := slogc.New(context.Background(), logger.With(slog.String("request", "r1")))
+		err := slogx.WrapError(ctx, errors.New("e1"), slog.Int("n", 1))
+		logger.Error(context.Background(), "failed", slogx.ErrorAttr(err))
+
+		t.Expect(lastRecord(cl).Attrs).To(Equal([]mock.Attr{
+			{Key: slogx.ErrorKey, Value: err},
+		}))
+	})
+}
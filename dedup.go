@@ -0,0 +1,353 @@
+package slogx
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Deduplicate returns a new handler that suppresses records identical in level, message and attributes
+// to the previous record passed through the handler or any handler derived from it within a time window.
+// The first record is passed to the underlying handler immediately, and the number of suppressed repeats, if any,
+// is reported by a single copy of the record with an additional attribute, see [DeduplicateOptions.CountKey],
+// when the window closes, when a different record arrives, or when the handler is flushed or closed.
+// Attribute values implementing [slog.LogValuer] are resolved once, before records are compared,
+// and the resolved record is passed to the underlying handler, so they are not resolved again.
+// See [DeduplicateOptions] for the available options, nil options mean default options.
+func Deduplicate(handler slog.Handler, options *DeduplicateOptions) slog.Handler {
+	if options == nil {
+		options = &DeduplicateOptions{}
+	}
+
+	state := &dedupState{
+		window:   options.Window,
+		clock:    options.Clock,
+		countKey: options.CountKey,
+	}
+
+	if state.window <= 0 {
+		state.window = defaultDedupWindow
+	}
+
+	if state.clock == nil {
+		state.clock = SystemClock()
+	}
+
+	if state.countKey == "" {
+		state.countKey = RepeatedKey
+	}
+
+	return &dedupHandler{handler, state}
+}
+
+// ---
+
+// DeduplicateOptions contains options for [Deduplicate].
+type DeduplicateOptions struct {
+	// Window is the duration after the first of identical records during which repeats are suppressed.
+	// The default is 1 second.
+	Window time.Duration
+	// Clock is the source of the current time and timers, [SystemClock] is used if it is nil.
+	Clock Clock
+	// CountKey is the key of the attribute containing the number of suppressed repeats, [RepeatedKey] is used if it is empty.
+	CountKey string
+}
+
+// RepeatedKey is the default key of the attribute containing the number of suppressed repeats, see [Deduplicate].
+const RepeatedKey = "repeated"
+
+// ---
+
+// Clock provides the current time and timers.
+// It allows handlers depending on time, like the one returned by [Deduplicate], to be tested with a fake clock,
+// see [github.com/pamburus/slogx/slogxtest.FakeClock].
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+	// AfterFunc calls the function in its own goroutine after the duration elapses, see [time.AfterFunc].
+	AfterFunc(d time.Duration, f func()) Timer
+}
+
+// Timer is a timer created by [Clock.AfterFunc].
+type Timer interface {
+	// Stop prevents the timer from firing, see [time.Timer.Stop].
+	Stop() bool
+}
+
+// SystemClock returns a [Clock] using the system time.
+func SystemClock() Clock {
+	return systemClock{}
+}
+
+// ---
+
+const defaultDedupWindow = time.Second
+
+// ---
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (systemClock) AfterFunc(d time.Duration, f func()) Timer {
+	return time.AfterFunc(d, f)
+}
+
+// ---
+
+type dedupHandler struct {
+	base  slog.Handler
+	state *dedupState
+}
+
+func (h *dedupHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.base.Enabled(ctx, level)
+}
+
+func (h *dedupHandler) Handle(ctx context.Context, record slog.Record) error {
+	return h.state.handle(ctx, h, record)
+}
+
+func (h *dedupHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+
+	return &dedupHandler{h.base.WithAttrs(attrs), h.state}
+}
+
+func (h *dedupHandler) WithGroup(key string) slog.Handler {
+	if key == "" {
+		return h
+	}
+
+	return &dedupHandler{h.base.WithGroup(key), h.state}
+}
+
+func (h *dedupHandler) Flush(ctx context.Context) error {
+	return errors.Join(h.state.flush(), Flush(ctx, h.base))
+}
+
+func (h *dedupHandler) Close(ctx context.Context) error {
+	return errors.Join(h.state.flush(), Close(ctx, h.base))
+}
+
+// ---
+
+type dedupState struct {
+	window   time.Duration
+	clock    Clock
+	countKey string
+
+	mu      sync.Mutex
+	pending *dedupEntry
+}
+
+type dedupEntry struct {
+	handler *dedupHandler
+	ctx     context.Context
+	key     string
+	record  slog.Record
+	start   time.Time
+	count   int
+	timer   Timer
+}
+
+// handle passes the record to the underlying handler unless it repeats the pending one.
+// The decision is made under the mutex, but the underlying handler is called after it is unlocked,
+// so slow handlers do not block other goroutines and handlers logging through the same handler do not deadlock.
+func (s *dedupState) handle(ctx context.Context, h *dedupHandler, record slog.Record) error {
+	record = resolveRecord(record)
+
+	summary, suppressed := s.track(ctx, h, record)
+	if suppressed {
+		return nil
+	}
+
+	return errors.Join(summary.emit(), h.base.Handle(ctx, record))
+}
+
+// track counts the record if it repeats the pending one, or makes it the pending one otherwise
+// and returns the summary of the previous pending entry.
+// The record must be built by [resolveRecord], so it is owned by the handler and can be kept without cloning.
+func (s *dedupState) track(ctx context.Context, h *dedupHandler, record slog.Record) (*dedupSummary, bool) {
+	key := dedupKey(record)
+	now := s.clock.Now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if p := s.pending; p != nil && p.handler == h && p.key == key && now.Sub(p.start) < s.window {
+		p.count++
+		p.record.Time = record.Time
+
+		return nil, true
+	}
+
+	summary := s.release()
+
+	entry := &dedupEntry{
+		handler: h,
+		ctx:     context.WithoutCancel(ctx),
+		key:     key,
+		record:  record,
+		start:   now,
+	}
+	entry.timer = s.clock.AfterFunc(s.window, func() {
+		_ = s.expire(entry).emit()
+	})
+	s.pending = entry
+
+	return summary, false
+}
+
+func (s *dedupState) expire(entry *dedupEntry) *dedupSummary {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.pending != entry {
+		return nil
+	}
+
+	return s.release()
+}
+
+func (s *dedupState) flush() error {
+	return s.take().emit()
+}
+
+func (s *dedupState) take() *dedupSummary {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.release()
+}
+
+// release forgets the pending entry and returns its summary if it has suppressed repeats.
+// It must be called with the mutex locked.
+func (s *dedupState) release() *dedupSummary {
+	p := s.pending
+	if p == nil {
+		return nil
+	}
+
+	s.pending = nil
+	p.timer.Stop()
+
+	if p.count == 0 {
+		return nil
+	}
+
+	record := p.record.Clone()
+	record.AddAttrs(slog.Int(s.countKey, p.count))
+
+	return &dedupSummary{p.handler, p.ctx, record}
+}
+
+// ---
+
+// dedupSummary is a record reporting the number of suppressed repeats to be passed to the underlying handler.
+type dedupSummary struct {
+	handler *dedupHandler
+	ctx     context.Context
+	record  slog.Record
+}
+
+func (s *dedupSummary) emit() error {
+	if s == nil {
+		return nil
+	}
+
+	return s.handler.base.Handle(s.ctx, s.record)
+}
+
+// ---
+
+// resolveRecord returns a copy of the record with all attribute values resolved, including those in groups.
+func resolveRecord(record slog.Record) slog.Record {
+	result := slog.NewRecord(record.Time, record.Level, record.Message, record.PC)
+
+	record.Attrs(func(attr slog.Attr) bool {
+		result.AddAttrs(resolveAttr(attr))
+
+		return true
+	})
+
+	return result
+}
+
+func resolveAttr(attr slog.Attr) slog.Attr {
+	attr.Value = attr.Value.Resolve()
+
+	if attr.Value.Kind() == slog.KindGroup && !resolved(attr.Value) {
+		group := attr.Value.Group()
+		members := make([]slog.Attr, len(group))
+
+		for i, member := range group {
+			members[i] = resolveAttr(member)
+		}
+
+		attr.Value = slog.GroupValue(members...)
+	}
+
+	return attr
+}
+
+func resolved(value slog.Value) bool {
+	switch value.Kind() {
+	case slog.KindLogValuer:
+		return false
+	case slog.KindGroup:
+		for _, attr := range value.Group() {
+			if !resolved(attr.Value) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// dedupKey returns the key identifying records that are repeats of each other.
+// The record must be built by [resolveRecord].
+func dedupKey(record slog.Record) string {
+	var b strings.Builder
+
+	b.WriteString(record.Level.String())
+	b.WriteByte(' ')
+	b.WriteString(strconv.Quote(record.Message))
+
+	record.Attrs(func(attr slog.Attr) bool {
+		writeDedupAttr(&b, attr)
+
+		return true
+	})
+
+	return b.String()
+}
+
+func writeDedupAttr(b *strings.Builder, attr slog.Attr) {
+	value := attr.Value
+
+	b.WriteByte(' ')
+	b.WriteString(strconv.Quote(attr.Key))
+	b.WriteByte('=')
+
+	if value.Kind() == slog.KindGroup {
+		b.WriteByte('{')
+		for _, attr := range value.Group() {
+			writeDedupAttr(b, attr)
+		}
+		b.WriteByte('}')
+
+		return
+	}
+
+	b.WriteString(strconv.Quote(value.String()))
+}
@@ -0,0 +1,213 @@
+package slogx_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/pamburus/go-tst/tst"
+	"github.com/pamburus/slogx"
+	"github.com/pamburus/slogx/slogxtest"
+)
+
+func TestDeduplicate(tt *testing.T) {
+	t := New(tt)
+	ctx := context.Background()
+	errE1 := errors.New("e1")
+
+	setup := func(options *slogx.DeduplicateOptions) (*bytes.Buffer, *slogxtest.FakeClock, slog.Handler) {
+		var buf bytes.Buffer
+
+		clock := slogxtest.NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+		if options == nil {
+			options = &slogx.DeduplicateOptions{}
+		}
+		options.Clock = clock
+
+		handler := slog.NewTextHandler(&buf, &slog.HandlerOptions{
+			ReplaceAttr: func(groups []string, attr slog.Attr) slog.Attr {
+				if len(groups) == 0 && attr.Key == slog.TimeKey {
+					return slog.Attr{}
+				}
+
+				return attr
+			},
+		})
+
+		return &buf, clock, slogx.Deduplicate(handler, options)
+	}
+
+	lines := func(buf *bytes.Buffer) []string {
+		return strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	}
+
+	t.Run("Window", func(t Test) {
+		buf, clock, handler := setup(nil)
+		logger := slogx.New(handler).WithSource(false)
+
+		for range 3 {
+			logger.Error("failed", slogx.ErrorAttr(errE1))
+			clock.Advance(100 * time.Millisecond)
+		}
+
+		t.Expect(lines(buf)).To(Equal([]string{"level=ERROR msg=failed error=e1"}))
+
+		clock.Advance(time.Second)
+		logger.Error("failed", slogx.ErrorAttr(errE1))
+
+		t.Expect(lines(buf)).To(Equal([]string{
+			"level=ERROR msg=failed error=e1",
+			"level=ERROR msg=failed error=e1 repeated=2",
+			"level=ERROR msg=failed error=e1",
+		}))
+	})
+
+	t.Run("Different", func(t Test) {
+		buf, _, handler := setup(&slogx.DeduplicateOptions{CountKey: "n"})
+		logger := slogx.New(handler).WithSource(false)
+
+		logger.Info("m1", slog.Int("a", 1))
+		logger.Info("m1", slog.Int("a", 1))
+		logger.Info("m1", slog.Int("a", 2))
+		logger.Warn("m1", slog.Int("a", 2))
+		logger.Warn("m2", slog.Int("a", 2))
+		logger.WithGroup("g").Warn("m2", slog.Int("a", 2))
+		logger.Warn("m2", slog.Group("g", slog.Int("a", 2)))
+		logger.Warn("m2", slog.Group("g", slog.Int("a", 2)))
+
+		t.Expect(lines(buf)).To(Equal([]string{
+			"level=INFO msg=m1 a=1",
+			"level=INFO msg=m1 a=1 n=1",
+			"level=INFO msg=m1 a=2",
+			"level=WARN msg=m1 a=2",
+			"level=WARN msg=m2 a=2",
+			"level=WARN msg=m2 g.a=2",
+			"level=WARN msg=m2 g.a=2",
+		}))
+
+		t.Expect(slogx.Flush(ctx, handler)).ToNot(HaveOccurred())
+		t.Expect(lines(buf)[7:]).To(Equal([]string{"level=WARN msg=m2 g.a=2 n=1"}))
+	})
+
+	t.Run("Derived", func(t Test) {
+		buf, clock, handler := setup(&slogx.DeduplicateOptions{Window: time.Minute})
+		logger := slogx.New(handler).WithSource(false).WithLongTerm(slog.String("s", "v"))
+
+		logger.Info("m1")
+		logger.Info("m1")
+		slogx.New(handler).WithSource(false).Info("m1")
+		logger.Info("m1")
+		clock.Advance(30 * time.Second)
+		t.Expect(lines(buf)).To(HaveLen(4))
+
+		clock.Advance(30 * time.Second)
+		t.Expect(lines(buf)).To(Equal([]string{
+			"level=INFO msg=m1 s=v",
+			"level=INFO msg=m1 s=v repeated=1",
+			"level=INFO msg=m1",
+			"level=INFO msg=m1 s=v",
+		}))
+	})
+
+	t.Run("Close", func(t Test) {
+		buf, _, handler := setup(nil)
+		logger := slogx.New(handler).WithSource(false)
+
+		logger.Info("m1")
+		logger.Info("m1")
+		t.Expect(slogx.Close(ctx, handler.WithGroup("g"))).ToNot(HaveOccurred())
+		t.Expect(slogx.Close(ctx, handler)).ToNot(HaveOccurred())
+
+		t.Expect(lines(buf)).To(Equal([]string{"level=INFO msg=m1", "level=INFO msg=m1 repeated=1"}))
+	})
+
+	t.Run("Reentrant", func(t Test) {
+		recorder := slogxtest.NewRecorder()
+		reentrant := &reentrantHandler{Handler: recorder}
+		handler := slogx.Deduplicate(reentrant, &slogx.DeduplicateOptions{Clock: slogxtest.NewFakeClock(time.Now())})
+		reentrant.logger = slogx.New(handler).WithSource(false)
+
+		reentrant.logger.Info("m1")
+		reentrant.logger.Info("m1")
+		t.Expect(slogx.Flush(ctx, handler)).ToNot(HaveOccurred())
+
+		var messages []string
+		for _, record := range recorder.Records() {
+			messages = append(messages, record.Message)
+		}
+
+		t.Expect(messages).To(Equal([]string{"m1", "handled m1", "m1", "handled m1"}))
+	})
+
+	t.Run("LogValuers", func(t Test) {
+		buf, _, handler := setup(nil)
+		logger := slogx.New(handler).WithSource(false)
+
+		v1 := &countingValuer{value: "v1"}
+		v2 := &countingValuer{value: "v2"}
+
+		for range 2 {
+			logger.Info("m1", slog.Any("a", v1), slog.Group("g", slog.Any("b", v2)))
+		}
+
+		t.Expect(slogx.Flush(ctx, handler)).ToNot(HaveOccurred())
+		t.Expect(lines(buf)).To(Equal([]string{
+			"level=INFO msg=m1 a=v1 g.b=v2",
+			"level=INFO msg=m1 a=v1 g.b=v2 repeated=1",
+		}))
+		t.Expect(v1.calls).To(Equal(2))
+		t.Expect(v2.calls).To(Equal(2))
+	})
+
+	t.Run("SystemClock", func(t Test) {
+		recorder := slogxtest.NewRecorder()
+		logger := slogx.New(slogx.Deduplicate(recorder, &slogx.DeduplicateOptions{Window: time.Millisecond}))
+
+		logger.Info("m1")
+		logger.Info("m1")
+
+		for i := 0; i < 1000 && len(recorder.Records()) < 2; i++ {
+			time.Sleep(time.Millisecond)
+		}
+
+		records := recorder.Records()
+		t.Expect(records).To(HaveLen(2))
+		t.Expect(records[1].NumAttrs()).To(Equal(1))
+		t.Expect(slogx.SystemClock().Now().IsZero()).To(BeFalse())
+	})
+}
+
+// ---
+
+// countingValuer counts calls to its LogValue method.
+type countingValuer struct {
+	value string
+	calls int
+}
+
+func (v *countingValuer) LogValue() slog.Value {
+	v.calls++
+
+	return slog.StringValue(v.value)
+}
+
+// ---
+
+// reentrantHandler logs a message through the logger each time it handles a record not logged by itself.
+type reentrantHandler struct {
+	slog.Handler
+	logger *slogx.Logger
+}
+
+func (h *reentrantHandler) Handle(ctx context.Context, record slog.Record) error {
+	err := h.Handler.Handle(ctx, record)
+	if !strings.HasPrefix(record.Message, "handled ") {
+		h.logger.Info("handled " + record.Message)
+	}
+
+	return err
+}
@@ -0,0 +1,140 @@
+package slogx
+
+import (
+	"log/slog"
+	"reflect"
+	"runtime"
+	"strconv"
+)
+
+// ErrorDetailsAttr returns an attribute with the error rendered as a group
+// containing its message, concrete type, attributes, stack trace and the chain of wrapped errors.
+// See [ErrorDetails] for details.
+func ErrorDetailsAttr(err error) slog.Attr {
+	return slog.Any(ErrorKey, ErrorDetails(err))
+}
+
+// ErrorDetails returns a [slog.LogValuer] that renders the error as a group with the following members:
+//   - "msg" containing the error message;
+//   - "type" containing the concrete type of the error;
+//   - attributes provided by the error if it implements [AttrCarrier];
+//   - "stack" containing the stack trace if the error provides it using StackTrace method
+//     returning a slice of program counters, like errors created by github.com/pkg/errors do;
+//   - "cause" containing the details of the error returned by Unwrap() error method;
+//   - "causes" containing the details of the errors returned by Unwrap() []error method, like errors created by [errors.Join].
+//
+// The value is rendered lazily, only if the handler resolves it.
+func ErrorDetails(err error) slog.LogValuer {
+	return errorDetails{err, 0}
+}
+
+// AttrCarrier is implemented by errors that carry attributes to be logged along with them.
+type AttrCarrier interface {
+	LogAttrs() []slog.Attr
+}
+
+// ---
+
+type errorDetails struct {
+	err   error
+	depth int
+}
+
+func (d errorDetails) LogValue() slog.Value {
+	if d.err == nil {
+		return slog.Value{}
+	}
+
+	if err, ok := d.err.(*contextError); ok {
+		return errorDetails{err.err, d.depth}.LogValue()
+	}
+
+	attrs := make([]slog.Attr, 0, 4)
+	attrs = append(attrs,
+		slog.String(errorDetailsKeyMsg, d.err.Error()),
+		slog.String(errorDetailsKeyType, reflect.TypeOf(d.err).String()),
+	)
+
+	if carrier, ok := d.err.(AttrCarrier); ok {
+		attrs = append(attrs, carrier.LogAttrs()...)
+	}
+
+	if stack := errorStackTrace(d.err); len(stack) != 0 {
+		attrs = append(attrs, slog.Any(errorDetailsKeyStack, formatStackTrace(stack)))
+	}
+
+	if d.depth < maxErrorDepth {
+		switch err := d.err.(type) {
+		case interface{ Unwrap() error }:
+			if cause := err.Unwrap(); cause != nil {
+				attrs = append(attrs, slog.Any(errorDetailsKeyCause, errorDetails{cause, d.depth + 1}))
+			}
+		case interface{ Unwrap() []error }:
+			causes := err.Unwrap()
+			members := make([]slog.Attr, 0, len(causes))
+
+			for i, cause := range causes {
+				if cause != nil {
+					members = append(members, slog.Any(strconv.Itoa(i), errorDetails{cause, d.depth + 1}))
+				}
+			}
+
+			attrs = append(attrs, slog.Attr{Key: errorDetailsKeyCauses, Value: slog.GroupValue(members...)})
+		}
+	}
+
+	return slog.GroupValue(attrs...)
+}
+
+// ---
+
+func errorStackTrace(err error) []uintptr {
+	method := reflect.ValueOf(err).MethodByName("StackTrace")
+	if !method.IsValid() || method.Type().NumIn() != 0 || method.Type().NumOut() != 1 {
+		return nil
+	}
+
+	result := method.Type().Out(0)
+	if result.Kind() != reflect.Slice || result.Elem().Kind() != reflect.Uintptr {
+		return nil
+	}
+
+	frames := method.Call(nil)[0]
+	stack := make([]uintptr, frames.Len())
+
+	for i := range stack {
+		stack[i] = uintptr(frames.Index(i).Uint())
+	}
+
+	return stack
+}
+
+func formatStackTrace(stack []uintptr) []string {
+	result := make([]string, 0, len(stack))
+	frames := runtime.CallersFrames(stack)
+
+	for {
+		frame, more := frames.Next()
+		if frame.Function != "" || frame.File != "" {
+			result = append(result, frame.Function+" "+frame.File+":"+strconv.Itoa(frame.Line))
+		}
+
+		if !more {
+			break
+		}
+	}
+
+	return result
+}
+
+// ---
+
+const (
+	errorDetailsKeyMsg    = "msg"
+	errorDetailsKeyType   = "type"
+	errorDetailsKeyStack  = "stack"
+	errorDetailsKeyCause  = "cause"
+	errorDetailsKeyCauses = "causes"
+)
+
+const maxErrorDepth = 32
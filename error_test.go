@@ -0,0 +1,99 @@
+package slogx_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"runtime"
+	"strings"
+	"testing"
+
+	. "github.com/pamburus/go-tst/tst"
+	"github.com/pamburus/slogx"
+)
+
+func TestErrorDetails(tt *testing.T) {
+	t := New(tt)
+
+	render := func(t Test, attr slog.Attr) map[string]any {
+		var buf bytes.Buffer
+
+		logger := slogx.New(slog.NewJSONHandler(&buf, nil)).WithSource(false)
+		logger.Info("msg", attr)
+
+		var result map[string]any
+		t.Expect(json.Unmarshal(buf.Bytes(), &result)).ToNot(HaveOccurred())
+
+		return result[slogx.ErrorKey].(map[string]any)
+	}
+
+	t.Run("Simple", func(t Test) {
+		t.Expect(render(t, slogx.ErrorDetailsAttr(errors.New("e1")))).To(Equal(map[string]any{
+			"msg":  "e1",
+			"type": "*errors.errorString",
+		}))
+	})
+
+	t.Run("Chain", func(t Test) {
+		err := fmt.Errorf("e2: %w", &testError{"e1", []slog.Attr{slog.Int("code", 42)}, nil})
+
+		t.Expect(render(t, slogx.ErrorDetailsAttr(err))).To(Equal(map[string]any{
+			"msg":  "e2: e1",
+			"type": "*fmt.wrapError",
+			"cause": map[string]any{
+				"msg":  "e1",
+				"type": "*slogx_test.testError",
+				"code": float64(42),
+			},
+		}))
+	})
+
+	t.Run("Join", func(t Test) {
+		err := errors.Join(errors.New("e1"), errors.New("e2"))
+
+		t.Expect(render(t, slogx.ErrorDetailsAttr(err))).To(Equal(map[string]any{
+			"msg":  "e1\ne2",
+			"type": "*errors.joinError",
+			"causes": map[string]any{
+				"0": map[string]any{"msg": "e1", "type": "*errors.errorString"},
+				"1": map[string]any{"msg": "e2", "type": "*errors.errorString"},
+			},
+		}))
+	})
+
+	t.Run("Stack", func(t Test) {
+		var pcs [1]uintptr
+		runtime.Callers(1, pcs[:])
+
+		result := render(t, slogx.ErrorDetailsAttr(&testError{"e1", nil, pcs[:]}))
+		t.Expect(result["stack"]).To(HaveLen(1))
+
+		frame := result["stack"].([]any)[0].(string)
+		t.Expect(strings.HasPrefix(frame, "github.com/pamburus/slogx_test.TestErrorDetails.func")).To(BeTrue())
+		t.Expect(strings.Contains(frame, "error_test.go:")).To(BeTrue())
+	})
+
+	t.Run("Nil", func(t Test) {
+		t.Expect(slogx.ErrorDetails(nil).LogValue()).To(Equal(slog.Value{}))
+	})
+}
+
+type testError struct {
+	msg   string
+	attrs []slog.Attr
+	stack []uintptr
+}
+
+func (e *testError) Error() string {
+	return e.msg
+}
+
+func (e *testError) LogAttrs() []slog.Attr {
+	return e.attrs
+}
+
+func (e *testError) StackTrace() []uintptr {
+	return e.stack
+}
@@ -0,0 +1,218 @@
+package slogx
+
+import (
+	"cmp"
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// Event returns a new [Event] for building a log record at the given level
+// if the level is enabled, otherwise it returns nil.
+// All methods of a nil [Event] do nothing, so attribute construction is skipped for disabled levels, for example:
+//
+//	logger.InfoEvent().Str("key", value).Int("count", 1).Msg("done")
+func (l *Logger) Event(level slog.Level) *Event {
+	return l.event(context.Background(), level)
+}
+
+// DebugEvent returns a new [Event] at the debug level, see [Logger.Event].
+func (l *Logger) DebugEvent() *Event {
+	return l.event(context.Background(), slog.LevelDebug)
+}
+
+// InfoEvent returns a new [Event] at the info level, see [Logger.Event].
+func (l *Logger) InfoEvent() *Event {
+	return l.event(context.Background(), slog.LevelInfo)
+}
+
+// WarnEvent returns a new [Event] at the warn level, see [Logger.Event].
+func (l *Logger) WarnEvent() *Event {
+	return l.event(context.Background(), slog.LevelWarn)
+}
+
+// ErrorEvent returns a new [Event] at the error level, see [Logger.Event].
+func (l *Logger) ErrorEvent() *Event {
+	return l.event(context.Background(), slog.LevelError)
+}
+
+// ---
+
+// Event returns a new [Event] for building a log record at the given level with the given context
+// if the level is enabled, otherwise it returns nil.
+// All methods of a nil [Event] do nothing, so attribute construction is skipped for disabled levels, for example:
+//
+//	logger.InfoEvent(ctx).Str("key", value).Int("count", 1).Msg("done")
+func (l *ContextLogger) Event(ctx context.Context, level slog.Level) *Event {
+	return l.event(ctx, level)
+}
+
+// DebugEvent returns a new [Event] at the debug level, see [ContextLogger.Event].
+func (l *ContextLogger) DebugEvent(ctx context.Context) *Event {
+	return l.event(ctx, slog.LevelDebug)
+}
+
+// InfoEvent returns a new [Event] at the info level, see [ContextLogger.Event].
+func (l *ContextLogger) InfoEvent(ctx context.Context) *Event {
+	return l.event(ctx, slog.LevelInfo)
+}
+
+// WarnEvent returns a new [Event] at the warn level, see [ContextLogger.Event].
+func (l *ContextLogger) WarnEvent(ctx context.Context) *Event {
+	return l.event(ctx, slog.LevelWarn)
+}
+
+// ErrorEvent returns a new [Event] at the error level, see [ContextLogger.Event].
+func (l *ContextLogger) ErrorEvent(ctx context.Context) *Event {
+	return l.event(ctx, slog.LevelError)
+}
+
+// ---
+
+// Event is a log record being built.
+// Attributes are added directly to the underlying [slog.Record], and the record is passed to the handler
+// by [Event.Msg] or [Event.Send].
+// Events are pooled, so an event must not be used after it is sent.
+// All methods of a nil Event do nothing.
+type Event struct {
+	logger *commonLogger
+	ctx    context.Context
+	record slog.Record
+}
+
+// Ctx sets the context passed to the handler.
+func (e *Event) Ctx(ctx context.Context) *Event {
+	if e != nil {
+		e.ctx = cmp.Or(ctx, context.Background())
+	}
+
+	return e
+}
+
+// Str adds a string attribute.
+func (e *Event) Str(key, value string) *Event {
+	return e.Attr(slog.String(key, value))
+}
+
+// Int adds an int attribute.
+func (e *Event) Int(key string, value int) *Event {
+	return e.Attr(slog.Int(key, value))
+}
+
+// Int64 adds an int64 attribute.
+func (e *Event) Int64(key string, value int64) *Event {
+	return e.Attr(slog.Int64(key, value))
+}
+
+// Uint64 adds an uint64 attribute.
+func (e *Event) Uint64(key string, value uint64) *Event {
+	return e.Attr(slog.Uint64(key, value))
+}
+
+// Float64 adds a float64 attribute.
+func (e *Event) Float64(key string, value float64) *Event {
+	return e.Attr(slog.Float64(key, value))
+}
+
+// Bool adds a bool attribute.
+func (e *Event) Bool(key string, value bool) *Event {
+	return e.Attr(slog.Bool(key, value))
+}
+
+// Dur adds a [time.Duration] attribute.
+func (e *Event) Dur(key string, value time.Duration) *Event {
+	return e.Attr(slog.Duration(key, value))
+}
+
+// Time adds a [time.Time] attribute.
+func (e *Event) Time(key string, value time.Time) *Event {
+	return e.Attr(slog.Time(key, value))
+}
+
+// Err adds an error attribute with [ErrorKey] key, see [ErrorAttr].
+func (e *Event) Err(err error) *Event {
+	if e != nil {
+		e.record.AddAttrs(ErrorAttr(err))
+	}
+
+	return e
+}
+
+// Any adds an attribute with an arbitrary value, see [slog.Any].
+func (e *Event) Any(key string, value any) *Event {
+	return e.Attr(slog.Any(key, value))
+}
+
+// Attr adds the attribute.
+func (e *Event) Attr(attr slog.Attr) *Event {
+	if e != nil {
+		e.record.AddAttrs(attr)
+	}
+
+	return e
+}
+
+// Attrs adds the attributes.
+func (e *Event) Attrs(attrs ...slog.Attr) *Event {
+	if e != nil {
+		e.record.AddAttrs(attrs...)
+	}
+
+	return e
+}
+
+// Msg sends the event with the given message to the handler.
+// The event must not be used after that.
+func (e *Event) Msg(msg string) {
+	if e != nil {
+		e.send(msg)
+	}
+}
+
+// Send sends the event with an empty message to the handler.
+// The event must not be used after that.
+func (e *Event) Send() {
+	if e != nil {
+		e.send("")
+	}
+}
+
+// ---
+
+func (e *Event) send(msg string) {
+	l := e.logger
+
+	e.record.Time = time.Now()
+	e.record.Message = msg
+
+	if l.sourceEnabled(e.record.Level) {
+		e.record.PC = callerPC(3)
+	}
+
+	_ = l.handler.Handle(e.ctx, e.record)
+
+	*e = Event{}
+	eventPool.Put(e)
+}
+
+func (l *commonLogger) event(ctx context.Context, level slog.Level) *Event {
+	ctx = cmp.Or(ctx, context.Background())
+
+	if !l.handler.Enabled(ctx, level) {
+		return nil
+	}
+
+	e := eventPool.Get().(*Event)
+	e.logger = l
+	e.ctx = ctx
+	e.record = l.newRecord(time.Time{}, level, "", 0)
+
+	return e
+}
+
+var eventPool = sync.Pool{
+	New: func() any {
+		return &Event{}
+	},
+}
@@ -0,0 +1,164 @@
+package slogx_test
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	. "github.com/pamburus/go-tst/tst"
+	"github.com/pamburus/slogx"
+	"github.com/pamburus/slogx/internal/mock"
+	"github.com/pamburus/slogx/slogc"
+)
+
+func TestEvent(tt *testing.T) {
+	t := New(tt)
+
+	setup := func() (*mock.CallLog, *slogx.Logger) {
+		cl := mock.NewCallLog()
+		handler := slogx.TweakHandler(mock.NewHandler(cl)).WithLevel(slog.LevelInfo).WithErrorContext().Result()
+
+		return cl, slogx.New(handler)
+	}
+
+	records := func(cl *mock.CallLog) []mock.Record {
+		var result []mock.Record
+
+		for _, call := range cl.Calls().WithoutTime() {
+			if call, ok := call.(mock.HandlerHandle); ok {
+				result = append(result, call.Record)
+			}
+		}
+
+		return result
+	}
+
+	t.Run("Attrs", func(t Test) {
+		cl, logger := setup()
+		ts := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+		err := errors.New("e1")
+
+		logger.With(slog.String("a", "x")).WithSource(false).InfoEvent().
+			Str("s", "v").
+			Int("i", 1).
+			Int64("i64", 2).
+			Uint64("u64", 3).
+			Float64("f", 1.5).
+			Bool("b", true).
+			Dur("d", time.Second).
+			Time("t", ts).
+			Err(err).
+			Any("any", []int{1}).
+			Attr(slog.Int("attr", 4)).
+			Attrs(slog.Int("attrs1", 5), slog.Int("attrs2", 6)).
+			Msg("m1")
+
+		t.Expect(records(cl)).To(Equal([]mock.Record{{
+			Level:   slog.LevelInfo,
+			Message: "m1",
+			Attrs: []mock.Attr{
+				{Key: "a", Value: "x"},
+				{Key: "s", Value: "v"},
+				{Key: "i", Value: int64(1)},
+				{Key: "i64", Value: int64(2)},
+				{Key: "u64", Value: uint64(3)},
+				{Key: "f", Value: 1.5},
+				{Key: "b", Value: true},
+				{Key: "d", Value: time.Second},
+				{Key: "t", Value: ts},
+				{Key: slogx.ErrorKey, Value: err},
+				{Key: "any", Value: []int{1}},
+				{Key: "attr", Value: int64(4)},
+				{Key: "attrs1", Value: int64(5)},
+				{Key: "attrs2", Value: int64(6)},
+			},
+		}}))
+	})
+
+	t.Run("Levels", func(t Test) {
+		cl, logger := setup()
+		logger = logger.WithSource(false)
+		ctx := context.Background()
+
+		logger.DebugEvent().Str("k", "v").Msg("m1")
+		logger.InfoEvent().Send()
+		logger.WarnEvent().Msg("m3")
+		logger.ErrorEvent().Ctx(ctx).Msg("m4")
+		logger.Event(slog.LevelDebug + 1).Msg("m5")
+		logger.ContextLogger().DebugEvent(ctx).Msg("m6")
+		logger.ContextLogger().InfoEvent(ctx).Msg("m7")
+		logger.ContextLogger().WarnEvent(ctx).Msg("m8")
+		logger.ContextLogger().ErrorEvent(ctx).Msg("m9")
+		logger.ContextLogger().Event(ctx, slog.LevelInfo+1).Msg("m10")
+
+		t.Expect(records(cl)).To(Equal([]mock.Record{
+			{Level: slog.LevelInfo},
+			{Level: slog.LevelWarn, Message: "m3"},
+			{Level: slog.LevelError, Message: "m4"},
+			{Level: slog.LevelInfo, Message: "m7"},
+			{Level: slog.LevelWarn, Message: "m8"},
+			{Level: slog.LevelError, Message: "m9"},
+			{Level: slog.LevelInfo + 1, Message: "m10"},
+		}))
+	})
+
+	t.Run("Source", func(t Test) {
+		cl, logger := setup()
+
+		sendEvents(logger)
+
+		result := records(cl)
+		t.Expect(result).To(HaveLen(2))
+		t.Expect(functionOf(result[0].PC)).To(Equal("github.com/pamburus/slogx_test.sendEvents"))
+		t.Expect(functionOf(result[1].PC)).To(Equal("github.com/pamburus/slogx_test.sendEvents"))
+	})
+
+	t.Run("ContextError", func(t Test) {
+		cl, logger := setup()
+		ctx := slogc.New(context.Background(), logger.ContextLogger().With(slog.String("req", "r1")))
+
+		logger.WithSource(false).ErrorEvent().Err(slogx.WrapError(ctx, errors.New("e1"))).Msg("m1")
+
+		result := records(cl)
+		t.Expect(result).To(HaveLen(1))
+		t.Expect(result[0].Attrs).To(HaveLen(2))
+		t.Expect(result[0].Attrs[1]).To(Equal(mock.Attr{Key: "req", Value: "r1"}))
+	})
+
+	t.Run("ManyAttrs", func(t Test) {
+		cl, logger := setup()
+
+		e := logger.WithSource(false).InfoEvent()
+		for i := range 20 {
+			e = e.Int("a", i)
+		}
+		e.Msg("m1")
+
+		e = logger.WithSource(false).InfoEvent()
+		e.Msg("m2")
+
+		result := records(cl)
+		t.Expect(result).To(HaveLen(2))
+		t.Expect(result[0].Attrs).To(HaveLen(20))
+		t.Expect(result[1].Attrs).To(HaveLen(0))
+	})
+
+	t.Run("Allocs", func(t Test) {
+		logger := slogx.New(&enabledDiscardHandler{}).WithSource(false)
+
+		allocs := testing.AllocsPerRun(100, func() {
+			logger.InfoEvent().Str("a", "av").Int("b", 1).Msg("m1")
+		})
+		t.Expect(allocs).To(BeZero())
+	})
+}
+
+// ---
+
+//go:noinline
+func sendEvents(logger *slogx.Logger) {
+	logger.InfoEvent().Msg("m1")
+	logger.InfoEvent().Send()
+}
@@ -0,0 +1,78 @@
+package slogx
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+)
+
+// Flusher is an optional interface implemented by handlers that buffer log records,
+// for example asynchronous, batching or network handlers.
+// Handlers provided by slogx that wrap other handlers, like the ones returned by [Join] and [TweakHandler],
+// implement it by propagating the call to the wrapped handlers.
+type Flusher interface {
+	// Flush writes any buffered log records.
+	Flush(ctx context.Context) error
+}
+
+// Closer is an optional interface implemented by handlers that hold resources that must be released,
+// for example files or network connections.
+// Handlers provided by slogx that wrap other handlers, like the ones returned by [Join] and [TweakHandler],
+// implement it by propagating the call to the wrapped handlers.
+type Closer interface {
+	// Close flushes any buffered log records and releases the resources.
+	// The handler must not be used after it is closed.
+	Close(ctx context.Context) error
+}
+
+// Flush flushes the handler if it implements [Flusher], otherwise it does nothing.
+func Flush(ctx context.Context, handler slog.Handler) error {
+	if flusher, ok := handler.(Flusher); ok {
+		return flusher.Flush(ctx)
+	}
+
+	return nil
+}
+
+// Close closes the handler if it implements [Closer], otherwise it flushes the handler using [Flush].
+func Close(ctx context.Context, handler slog.Handler) error {
+	if closer, ok := handler.(Closer); ok {
+		return closer.Close(ctx)
+	}
+
+	return Flush(ctx, handler)
+}
+
+// Shutdown flushes the handler tree of the default logger, see [slog.Default].
+// It is called by [Exit] before the exit hooks and should be called before the program exits in any other way.
+func Shutdown(ctx context.Context) error {
+	return Flush(ctx, defaultHandler())
+}
+
+// ---
+
+func flushAll(ctx context.Context, handlers []slog.Handler) error {
+	var errs []error
+
+	for _, handler := range handlers {
+		err := Flush(ctx, handler)
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func closeAll(ctx context.Context, handlers []slog.Handler) error {
+	var errs []error
+
+	for _, handler := range handlers {
+		err := Close(ctx, handler)
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,94 @@
+package slogx_test
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+
+	. "github.com/pamburus/go-tst/tst"
+	"github.com/pamburus/slogx"
+)
+
+func TestFlush(tt *testing.T) {
+	t := New(tt)
+	ctx := context.Background()
+	errE2 := errors.New("e2")
+
+	t.Run("Join", func(t Test) {
+		var log []string
+
+		handler := slogx.Join(
+			&flushingHandler{"h1", &log, nil},
+			slogx.Discard(),
+			slogx.TweakHandler(&flushingHandler{"h2", &log, errE2}).WithLevel(slog.LevelWarn).Result(),
+		)
+
+		t.Expect(slogx.Flush(ctx, handler)).To(MatchError(errE2))
+		t.Expect(log).To(Equal([]string{"flush h1", "flush h2"}))
+
+		log = nil
+		t.Expect(slogx.Close(ctx, handler)).To(MatchError(errE2))
+		t.Expect(log).To(Equal([]string{"close h1", "close h2"}))
+	})
+
+	t.Run("Derived", func(t Test) {
+		var log []string
+
+		handler := slogx.TweakHandler(&flushingHandler{"h1", &log, nil}).Result().WithGroup("g").WithAttrs([]slog.Attr{slog.Int("a", 1)})
+
+		t.Expect(slogx.New(handler).With(slog.Int("b", 2)).Flush(ctx)).ToNot(HaveOccurred())
+		t.Expect(log).To(Equal([]string{"flush h1"}))
+	})
+
+	t.Run("NotSupported", func(t Test) {
+		t.Expect(slogx.Flush(ctx, slogx.Discard())).ToNot(HaveOccurred())
+		t.Expect(slogx.Close(ctx, slog.NewTextHandler(nil, nil))).ToNot(HaveOccurred())
+	})
+
+	t.Run("Shutdown", func(t Test) {
+		var log []string
+
+		defer slog.SetDefault(slog.Default())
+		slog.SetDefault(slog.New(&flushingHandler{"h1", &log, nil}))
+
+		t.Expect(slogx.Shutdown(ctx)).ToNot(HaveOccurred())
+		t.Expect(log).To(Equal([]string{"flush h1"}))
+	})
+}
+
+// ---
+
+type flushingHandler struct {
+	name string
+	log  *[]string
+	err  error
+}
+
+func (h *flushingHandler) Enabled(context.Context, slog.Level) bool {
+	return true
+}
+
+func (h *flushingHandler) Handle(context.Context, slog.Record) error {
+	return nil
+}
+
+func (h *flushingHandler) WithAttrs([]slog.Attr) slog.Handler {
+	return h
+}
+
+func (h *flushingHandler) WithGroup(string) slog.Handler {
+	return h
+}
+
+func (h *flushingHandler) Flush(context.Context) error {
+	*h.log = append(*h.log, "flush "+h.name)
+
+	return h.err
+}
+
+func (h *flushingHandler) Close(context.Context) error {
+	*h.log = append(*h.log, "close "+h.name)
+
+	return h.err
+}
@@ -4,6 +4,10 @@ import (
 	"context"
 	"errors"
 	"log/slog"
+	"slices"
+	"strings"
+	"sync"
+	"time"
 )
 
 // Join returns a new handler that joins the provided handlers.
@@ -43,48 +47,319 @@ func (b TweakHandlerBuilder) WithDynamicAttr(attr func(context.Context) slog.Att
 	return b
 }
 
+// WithRedaction adds redaction of sensitive attribute values according to the given rules.
+// Values of types implementing [Redactable] are replaced by their redacted representation regardless of the rules.
+// Redaction applies both to the attributes passed to [slog.Handler.WithAttrs] and to the attributes of each record.
+func (b TweakHandlerBuilder) WithRedaction(rules ...RedactionRule) TweakHandlerBuilder {
+	b.tweaks.attrReplacers = append(slices.Clip(b.tweaks.attrReplacers), newRedaction(rules).stage())
+
+	return b
+}
+
+// WithAttrReplacer adds a transformation stage for attributes similar to [slog.HandlerOptions.ReplaceAttr]
+// that works for any underlying handler.
+// The replacer is called for each attribute with a resolved value and the list of keys of the enclosing groups,
+// including the groups opened using [slog.Handler.WithGroup].
+// Unlike [slog.HandlerOptions.ReplaceAttr], it is also called for group attributes after their members.
+// If the replacer returns an empty [slog.Attr], the attribute is dropped.
+// If the replacer returns a group with an empty key, its members are inlined into the enclosing group.
+// The attributes returned by the replacer are not passed to the same replacer again.
+// Stages are applied one after another in the order they were added
+// both to the attributes passed to [slog.Handler.WithAttrs] and to the attributes of each record.
+func (b TweakHandlerBuilder) WithAttrReplacer(replacer AttrReplacer) TweakHandlerBuilder {
+	b.tweaks.attrReplacers = append(slices.Clip(b.tweaks.attrReplacers), attrStage{replace: replacer})
+
+	return b
+}
+
+// WithLevel sets the minimum level of records accepted by the handler.
+// The level replaces the decision of the underlying handler's Enabled method,
+// so it can be used both to raise and to lower verbosity of the underlying handler.
+// The underlying handler's own level is not consulted then, and since handlers like [slog.JSONHandler]
+// do not check the level again in their Handle method, records below their own level are handled as well.
+// To only restrict the underlying handler, pass a level not lower than its own level.
+// The same applies to the levels set by [TweakHandlerBuilder.WithGroupLevel] and [TweakHandlerBuilder.WithContextLevel].
+// The level can be changed dynamically if it is a [slog.LevelVar].
+func (b TweakHandlerBuilder) WithLevel(level slog.Leveler) TweakHandlerBuilder {
+	b.tweaks.level = level
+
+	return b
+}
+
+// WithGroupLevel sets the minimum level of records accepted by the handlers derived from the handler
+// using [slog.Handler.WithGroup] with the given sequence of group keys or any sequence starting with it.
+// The most specific group level takes precedence over the level set by [TweakHandlerBuilder.WithLevel].
+func (b TweakHandlerBuilder) WithGroupLevel(level slog.Leveler, groups ...string) TweakHandlerBuilder {
+	if len(groups) != 0 {
+		b.tweaks.groupLevels = append(slices.Clip(b.tweaks.groupLevels), groupLevel{slices.Clone(groups), level})
+	}
+
+	return b
+}
+
+// WithContextLevel adds a function that provides the minimum level of records accepted by the handler
+// based on the context, see [github.com/pamburus/slogx/slogc.NameLevel] for example.
+// If the function returns nil, the next function is consulted, and finally the group level or the level
+// set by [TweakHandlerBuilder.WithLevel] is used.
+func (b TweakHandlerBuilder) WithContextLevel(level func(context.Context) slog.Leveler) TweakHandlerBuilder {
+	b.tweaks.contextLevels = append(slices.Clip(b.tweaks.contextLevels), level)
+
+	return b
+}
+
+// WithLevelRules adds rules changing the level of records before they are passed to the underlying handler.
+// Rules are applied in the order they were added, each rule sees the level resulting from the previous rules.
+// The handler reports that a level is enabled if it is enabled itself or if any rule can raise it to an enabled level,
+// and records ending up at a disabled level after applying the rules are dropped.
+func (b TweakHandlerBuilder) WithLevelRules(rules ...LevelRule) TweakHandlerBuilder {
+	b.tweaks.levelRules = append(slices.Clip(b.tweaks.levelRules), rules...)
+
+	return b
+}
+
+// WithMessageTemplate sets a template for messages of records passed to the underlying handler.
+// All occurrences of "{msg}" in the template are replaced with the original message,
+// so, for example, "db: {msg}" adds a prefix to each message.
+// It is applied after the level rules added by [TweakHandlerBuilder.WithLevelRules].
+func (b TweakHandlerBuilder) WithMessageTemplate(template string) TweakHandlerBuilder {
+	return b.WithMessageFunc(func(_ context.Context, record slog.Record) string {
+		return strings.ReplaceAll(template, "{msg}", record.Message)
+	})
+}
+
+// WithMessageFunc sets a function that provides messages of records passed to the underlying handler
+// based on the original record.
+// It is applied after the level rules added by [TweakHandlerBuilder.WithLevelRules].
+func (b TweakHandlerBuilder) WithMessageFunc(message func(context.Context, slog.Record) string) TweakHandlerBuilder {
+	b.tweaks.message = message
+
+	return b
+}
+
+// WithStaticAttrs adds attributes that do not change during the lifetime of the handler,
+// such as host name, service name or version.
+// Their values are resolved once and passed to the underlying handler's WithAttrs method when the handler is built,
+// so they do not cause any overhead per record.
+func (b TweakHandlerBuilder) WithStaticAttrs(attrs ...slog.Attr) TweakHandlerBuilder {
+	b.tweaks.staticAttrs = append(slices.Clip(b.tweaks.staticAttrs), attrs...)
+
+	return b
+}
+
+// WithLazyAttr adds an attribute with the value provided by the given function that is evaluated
+// only if a record actually reaches the underlying handler and the handler resolves the attribute value.
+// It is useful for expensive values, such as memory statistics.
+// If ttl is positive, the evaluated value is cached and reused for records handled during ttl.
+// Unlike [TweakHandlerBuilder.WithDynamicAttr], the function does not depend on the context.
+func (b TweakHandlerBuilder) WithLazyAttr(key string, ttl time.Duration, value func() slog.Value) TweakHandlerBuilder {
+	b.tweaks.lazyAttrs = append(slices.Clip(b.tweaks.lazyAttrs), slog.Any(key, &lazyValue{value: value, ttl: ttl}))
+
+	return b
+}
+
+// WithSourceOptions returns a new [TweakHandlerBuilder] with source location rendering enabled.
+// The source location determined by the record's program counter is rendered as a [slog.SourceKey] attribute
+// according to the options, and the program counter is then cleared, so the underlying handler does not add its own source.
+// Like in the built-in handlers, the source attribute is placed at the top level, outside of the groups
+// opened using [slog.Handler.WithGroup].
+// Note that to achieve that, the groups and attributes added after the first group are passed again
+// to the underlying handler for each record with a source location.
+func (b TweakHandlerBuilder) WithSourceOptions(options SourceOptions) TweakHandlerBuilder {
+	b.tweaks.source = &options
+
+	return b
+}
+
+// WithErrorContext enables adding the attributes captured by errors returned by [WrapError]
+// to the records containing such errors as attribute values.
+// The captured attributes that the record already has or that were added to this handler
+// or its ancestor using [slog.Handler.WithAttrs] are not added again.
+// To recognize the latter, the logger stored in the context passed to [WrapError]
+// must use this handler or a handler derived from it using [slog.Handler.WithAttrs] and [slog.Handler.WithGroup].
+// Other handlers do not pay for searching the errors in the records.
+func (b TweakHandlerBuilder) WithErrorContext() TweakHandlerBuilder {
+	b.tweaks.errorContext = true
+
+	return b
+}
+
 // Result returns the new handler.
 func (b TweakHandlerBuilder) Result() slog.Handler {
-	return &tweakedHandler{b.handler, b.tweaks}
+	tweaks := b.tweaks
+	tweaks.staticAttrs = nil
+
+	handler := &tweakedHandler{
+		base:          b.handler,
+		level:         tweaks.levelFor(nil),
+		handlerTweaks: tweaks,
+	}
+
+	if len(b.tweaks.staticAttrs) != 0 {
+		attrs := make([]slog.Attr, len(b.tweaks.staticAttrs))
+		for i, attr := range b.tweaks.staticAttrs {
+			attrs[i] = slog.Attr{Key: attr.Key, Value: attr.Value.Resolve()}
+		}
+
+		return handler.WithAttrs(attrs)
+	}
+
+	return handler
 }
 
 // ---
 
 type handlerTweaks struct {
-	dynamicAttrs []func(context.Context) slog.Attr
+	dynamicAttrs  []func(context.Context) slog.Attr
+	attrReplacers []attrStage
+	level         slog.Leveler
+	groupLevels   []groupLevel
+	contextLevels []func(context.Context) slog.Leveler
+	levelRules    []LevelRule
+	message       func(context.Context, slog.Record) string
+	staticAttrs   []slog.Attr
+	lazyAttrs     []slog.Attr
+	source        *SourceOptions
+	errorContext  bool
+}
+
+func (t *handlerTweaks) levelFor(groups []string) slog.Leveler {
+	level := t.level
+	depth := 0
+
+	for _, gl := range t.groupLevels {
+		if len(gl.groups) > depth && len(gl.groups) <= len(groups) && slices.Equal(gl.groups, groups[:len(gl.groups)]) {
+			level = gl.level
+			depth = len(gl.groups)
+		}
+	}
+
+	return level
+}
+
+// ---
+
+type lazyValue struct {
+	value   func() slog.Value
+	ttl     time.Duration
+	mu      sync.Mutex
+	cached  slog.Value
+	expires time.Time
+}
+
+func (v *lazyValue) LogValue() slog.Value {
+	if v.ttl <= 0 {
+		return v.value()
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	now := time.Now()
+	if now.Before(v.expires) {
+		return v.cached
+	}
+
+	v.cached = v.value()
+	v.expires = now.Add(v.ttl)
+
+	return v.cached
+}
+
+// ---
+
+type groupLevel struct {
+	groups []string
+	level  slog.Leveler
 }
 
 // ---
 
 type tweakedHandler struct {
-	base slog.Handler
+	base   slog.Handler
+	groups []string
+	level  slog.Leveler
+	scope  *attrScope
+	top    slog.Handler
+	topAt  *attrScope
 	handlerTweaks
 }
 
 func (h *tweakedHandler) Enabled(ctx context.Context, level slog.Level) bool {
-	return h.base.Enabled(ctx, level)
+	if h.enabled(ctx, level) {
+		return true
+	}
+
+	for _, rule := range h.levelRules {
+		if rule.mayRaise(level) && h.enabled(ctx, rule.level) {
+			return true
+		}
+	}
+
+	return false
 }
 
 func (h *tweakedHandler) Handle(ctx context.Context, record slog.Record) error {
-	if len(h.dynamicAttrs) != 0 {
-		record = record.Clone()
+	if len(h.levelRules) != 0 {
+		for _, rule := range h.levelRules {
+			record.Level = rule.apply(ctx, record)
+		}
 
-		for _, attr := range h.dynamicAttrs {
-			if attr := attr(ctx); !attr.Equal(slog.Attr{}) {
-				record.AddAttrs(attr)
-			}
+		if !h.enabled(ctx, record.Level) {
+			return nil
+		}
+	}
+
+	if h.message != nil {
+		record.Message = h.message(ctx, record)
+	}
+
+	var buf [4]slog.Attr
+
+	base := h.base
+	extra := buf[:0]
+
+	if h.errorContext && record.NumAttrs() != 0 {
+		extra = append(extra, errorContextAttrs(record, h.scope)...)
+	}
+
+	if h.source != nil && record.PC != 0 {
+		if h.top == nil {
+			extra = append(extra, h.source.attr(record.PC))
+		} else {
+			base = h.sourceHandler(h.source.attr(record.PC))
 		}
+
+		record.PC = 0
 	}
 
-	return h.base.Handle(ctx, record)
+	switch {
+	case len(h.attrReplacers) != 0:
+		record = h.transformRecord(ctx, record, extra)
+	case len(extra) != 0 || len(h.dynamicAttrs) != 0 || len(h.lazyAttrs) != 0:
+		record = record.Clone()
+		record.AddAttrs(h.appendExtraAttrs(ctx, extra)...)
+	}
+
+	return base.Handle(ctx, record)
 }
 
 func (h *tweakedHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(h.attrReplacers) != 0 {
+		attrs = h.transformAttrs(h.groups, attrs)
+	}
+
 	if len(attrs) == 0 {
 		return h
 	}
 
-	return &tweakedHandler{h.base.WithAttrs(attrs), h.handlerTweaks}
+	h = h.clone()
+	h.base = h.base.WithAttrs(attrs)
+
+	if h.tracksScope() {
+		h.scope = &attrScope{parent: h.scope, attrs: attrs}
+	}
+
+	return h
 }
 
 func (h *tweakedHandler) WithGroup(key string) slog.Handler {
@@ -92,7 +367,168 @@ func (h *tweakedHandler) WithGroup(key string) slog.Handler {
 		return h
 	}
 
-	return &tweakedHandler{h.base.WithGroup(key), h.handlerTweaks}
+	h = h.clone()
+
+	if h.source != nil && h.top == nil {
+		h.top = h.base
+		h.topAt = h.scope
+	}
+
+	h.base = h.base.WithGroup(key)
+	h.groups = append(slices.Clip(h.groups), key)
+
+	if h.tracksScope() {
+		h.scope = &attrScope{parent: h.scope, group: key}
+	}
+
+	if len(h.groupLevels) != 0 {
+		h.level = h.levelFor(h.groups)
+	}
+
+	return h
+}
+
+func (h *tweakedHandler) Flush(ctx context.Context) error {
+	return Flush(ctx, h.base)
+}
+
+func (h *tweakedHandler) Close(ctx context.Context) error {
+	return Close(ctx, h.base)
+}
+
+func (h *tweakedHandler) enabled(ctx context.Context, level slog.Level) bool {
+	if minLevel := h.minLevel(ctx); minLevel != nil {
+		return level >= minLevel.Level()
+	}
+
+	return h.base.Enabled(ctx, level)
+}
+
+func (h *tweakedHandler) minLevel(ctx context.Context) slog.Leveler {
+	for _, level := range h.contextLevels {
+		if level := level(ctx); level != nil {
+			return level
+		}
+	}
+
+	return h.level
+}
+
+func (h *tweakedHandler) transformRecord(ctx context.Context, record slog.Record, extra []slog.Attr) slog.Record {
+	attrs := make([]slog.Attr, 0, record.NumAttrs()+len(extra)+len(h.dynamicAttrs)+len(h.lazyAttrs))
+
+	record.Attrs(func(attr slog.Attr) bool {
+		attrs = append(attrs, attr)
+
+		return true
+	})
+
+	attrs = append(attrs, extra...)
+
+	attrs = h.appendExtraAttrs(ctx, attrs)
+
+	result := slog.NewRecord(record.Time, record.Level, record.Message, record.PC)
+	result.AddAttrs(h.transformAttrs(h.groups, attrs)...)
+
+	return result
+}
+
+func (h *tweakedHandler) appendExtraAttrs(ctx context.Context, attrs []slog.Attr) []slog.Attr {
+	for _, attr := range h.dynamicAttrs {
+		if attr := attr(ctx); !attr.Equal(slog.Attr{}) {
+			attrs = append(attrs, attr)
+		}
+	}
+
+	return append(attrs, h.lazyAttrs...)
+}
+
+// sourceHandler returns the underlying handler with the source attribute added at the top level.
+// It adds the attribute to the underlying handler saved before the first group was opened
+// and then adds the groups and attributes added to the handler since then once again.
+func (h *tweakedHandler) sourceHandler(source slog.Attr) slog.Handler {
+	attrs := []slog.Attr{source}
+	if len(h.attrReplacers) != 0 {
+		attrs = h.transformAttrs(nil, attrs)
+	}
+
+	var scopes []*attrScope
+	for s := h.scope; s != h.topAt; s = s.parent {
+		scopes = append(scopes, s)
+	}
+
+	handler := h.top.WithAttrs(attrs)
+	for i := len(scopes) - 1; i >= 0; i-- {
+		if scopes[i].group != "" {
+			handler = handler.WithGroup(scopes[i].group)
+		} else {
+			handler = handler.WithAttrs(scopes[i].attrs)
+		}
+	}
+
+	return handler
+}
+
+func (h *tweakedHandler) tracksScope() bool {
+	return h.errorContext || h.source != nil
+}
+
+func (h tweakedHandler) clone() *tweakedHandler {
+	return &h
+}
+
+func (h *tweakedHandler) transformAttrs(groups []string, attrs []slog.Attr) []slog.Attr {
+	for _, stage := range h.attrReplacers {
+		attrs = replaceAttrs(stage, groups, attrs)
+	}
+
+	return attrs
+}
+
+// ---
+
+// attrStage is a stage of the attribute transformation pipeline.
+type attrStage struct {
+	replace AttrReplacer
+	// resolve, if not nil, is used instead of [slog.Value.Resolve] to resolve values before they are replaced.
+	resolve func(slog.Value) slog.Value
+}
+
+func replaceAttrs(stage attrStage, groups []string, attrs []slog.Attr) []slog.Attr {
+	result := make([]slog.Attr, 0, len(attrs))
+
+	for _, attr := range attrs {
+		attr = replaceAttr(stage, groups, attr)
+
+		switch {
+		case attr.Equal(slog.Attr{}):
+		case attr.Key == "" && attr.Value.Kind() == slog.KindGroup:
+			result = append(result, attr.Value.Group()...)
+		default:
+			result = append(result, attr)
+		}
+	}
+
+	return result
+}
+
+func replaceAttr(stage attrStage, groups []string, attr slog.Attr) slog.Attr {
+	if stage.resolve != nil {
+		attr.Value = stage.resolve(attr.Value)
+	} else {
+		attr.Value = attr.Value.Resolve()
+	}
+
+	if attr.Value.Kind() == slog.KindGroup {
+		members := groups
+		if attr.Key != "" {
+			members = append(slices.Clip(groups), attr.Key)
+		}
+
+		attr.Value = slog.GroupValue(replaceAttrs(stage, members, attr.Value.Group())...)
+	}
+
+	return stage.replace(groups, attr)
 }
 
 // ---
@@ -175,3 +611,11 @@ func (h *multiHandler) WithGroup(key string) slog.Handler {
 
 	return &multiHandler{handlers}
 }
+
+func (h *multiHandler) Flush(ctx context.Context) error {
+	return flushAll(ctx, h.handlers)
+}
+
+func (h *multiHandler) Close(ctx context.Context) error {
+	return closeAll(ctx, h.handlers)
+}
@@ -0,0 +1,285 @@
+package slogx_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/pamburus/go-tst/tst"
+	"github.com/pamburus/slogx"
+	"github.com/pamburus/slogx/internal/mock"
+)
+
+func TestTweakHandlerLevel(tt *testing.T) {
+	t := New(tt)
+
+	ctx := context.Background()
+	someTime := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("Default", func(t Test) {
+		cl := mock.NewCallLog()
+		handler := slogx.TweakHandler(mock.NewHandler(cl)).Result()
+
+		t.Expect(handler.Enabled(ctx, slog.LevelDebug)).To(BeTrue())
+		t.Expect(cl.Calls()...).To(Equal(
+			mock.HandlerEnabled{Instance: "0", Level: slog.LevelDebug},
+		))
+	})
+
+	t.Run("Fixed", func(t Test) {
+		cl := mock.NewCallLog()
+		handler := slogx.TweakHandler(mock.NewHandler(cl)).
+			WithLevel(slog.LevelWarn).
+			Result()
+
+		t.Expect(handler.Enabled(ctx, slog.LevelInfo)).To(BeFalse())
+		t.Expect(handler.Enabled(ctx, slog.LevelWarn)).To(BeTrue())
+		t.Expect(cl.Calls()).To(BeZero())
+	})
+
+	t.Run("OverridesBase", func(t Test) {
+		var buf bytes.Buffer
+
+		base := slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelError})
+		handler := slogx.TweakHandler(base).WithLevel(slog.LevelInfo).Result()
+
+		t.Expect(base.Enabled(ctx, slog.LevelInfo)).To(BeFalse())
+		t.Expect(handler.Enabled(ctx, slog.LevelInfo)).To(BeTrue())
+		t.Expect(handler.Enabled(ctx, slog.LevelDebug)).To(BeFalse())
+		t.Expect(handler.Handle(ctx, slog.NewRecord(time.Time{}, slog.LevelInfo, "m1", 0))).ToNot(HaveOccurred())
+		t.Expect(buf.String()).To(Equal("level=INFO msg=m1\n"))
+	})
+
+	t.Run("LevelVar", func(t Test) {
+		var level slog.LevelVar
+
+		handler := slogx.TweakHandler(slogx.Discard()).
+			WithLevel(&level).
+			Result()
+
+		t.Expect(handler.Enabled(ctx, slog.LevelDebug)).To(BeFalse())
+		level.Set(slog.LevelDebug)
+		t.Expect(handler.Enabled(ctx, slog.LevelDebug)).To(BeTrue())
+	})
+
+	t.Run("Group", func(t Test) {
+		handler := slogx.TweakHandler(slogx.Discard()).
+			WithLevel(slog.LevelInfo).
+			WithGroupLevel(slog.LevelError, "a").
+			WithGroupLevel(slog.LevelDebug, "a", "b").
+			Result()
+
+		t.Expect(handler.Enabled(ctx, slog.LevelInfo)).To(BeTrue())
+		t.Expect(handler.WithGroup("x").Enabled(ctx, slog.LevelInfo)).To(BeTrue())
+		t.Expect(handler.WithGroup("a").Enabled(ctx, slog.LevelWarn)).To(BeFalse())
+		t.Expect(handler.WithGroup("a").WithGroup("c").Enabled(ctx, slog.LevelWarn)).To(BeFalse())
+		t.Expect(handler.WithGroup("a").WithGroup("b").Enabled(ctx, slog.LevelDebug)).To(BeTrue())
+		t.Expect(handler.WithGroup("a").WithGroup("b").WithGroup("c").Enabled(ctx, slog.LevelDebug)).To(BeTrue())
+	})
+
+	t.Run("Context", func(t Test) {
+		type key struct{}
+
+		handler := slogx.TweakHandler(slogx.Discard()).
+			WithLevel(slog.LevelError).
+			WithContextLevel(func(ctx context.Context) slog.Leveler {
+				level, _ := ctx.Value(key{}).(slog.Leveler)
+
+				return level
+			}).
+			Result()
+
+		t.Expect(handler.Enabled(ctx, slog.LevelInfo)).To(BeFalse())
+		t.Expect(handler.Enabled(context.WithValue(ctx, key{}, slog.LevelInfo), slog.LevelInfo)).To(BeTrue())
+	})
+
+	t.Run("Join", func(t Test) {
+		cl := mock.NewCallLog()
+		handler := slogx.Join(
+			slogx.TweakHandler(mock.NewHandler(cl)).WithLevel(slog.LevelError).Result(),
+			slogx.TweakHandler(mock.NewHandler(cl)).WithLevel(slog.LevelDebug).Result(),
+		)
+
+		t.Expect(handler.Enabled(ctx, slog.LevelDebug)).To(BeTrue())
+		t.Expect(handler.Handle(ctx, slog.NewRecord(someTime, slog.LevelInfo, "m1", 0))).ToNot(HaveOccurred())
+		t.Expect(cl.Calls()...).To(Equal(
+			mock.HandlerHandle{
+				Instance: "0",
+				Record: mock.Record{
+					Time:    someTime,
+					Message: "m1",
+					Level:   slog.LevelInfo,
+				},
+			},
+		))
+	})
+}
+
+func TestTweakHandlerRewriting(tt *testing.T) {
+	t := New(tt)
+
+	ctx := context.Background()
+	someTime := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("MessageTemplate", func(t Test) {
+		cl := mock.NewCallLog()
+		handler := slogx.TweakHandler(mock.NewHandler(cl)).
+			WithMessageTemplate("db: {msg}").
+			Result()
+
+		t.Expect(handler.Handle(ctx, slog.NewRecord(someTime, slog.LevelInfo, "m1", 0))).ToNot(HaveOccurred())
+		t.Expect(cl.Calls()...).To(Equal(
+			mock.HandlerHandle{
+				Instance: "0",
+				Record: mock.Record{
+					Time:    someTime,
+					Message: "db: m1",
+					Level:   slog.LevelInfo,
+				},
+			},
+		))
+	})
+
+	t.Run("LevelRules", func(t Test) {
+		cl := mock.NewCallLog()
+		handler := slogx.TweakHandler(mock.NewHandler(cl)).
+			WithLevel(slog.LevelInfo).
+			WithLevelRules(
+				slogx.PromoteLevelIf(slog.LevelError, slogx.HasAttr(slogx.ErrorKey)),
+				slogx.DemoteLevelIf(slog.LevelDebug, slogx.MessageMatches(regexp.MustCompile(`^noisy`))),
+			).
+			Result()
+
+		t.Expect(handler.Enabled(ctx, slog.LevelDebug)).To(BeTrue())
+		t.Expect(handler.Enabled(ctx, slog.LevelError+1)).To(BeTrue())
+
+		record1 := slog.NewRecord(someTime, slog.LevelDebug, "m1", 0)
+		record1.AddAttrs(slogx.ErrorAttr(errors.New("e1")))
+		record2 := slog.NewRecord(someTime, slog.LevelWarn, "noisy m2", 0)
+		record3 := slog.NewRecord(someTime, slog.LevelDebug, "m3", 0)
+		record4 := slog.NewRecord(someTime, slog.LevelWarn, "m4", 0)
+
+		for _, record := range []slog.Record{record1, record2, record3, record4} {
+			t.Expect(handler.Handle(ctx, record)).ToNot(HaveOccurred())
+		}
+
+		t.Expect(cl.Calls()...).To(Equal(
+			mock.HandlerHandle{
+				Instance: "0",
+				Record: mock.Record{
+					Time:    someTime,
+					Message: "m1",
+					Level:   slog.LevelError,
+					Attrs:   []mock.Attr{{Key: slogx.ErrorKey, Value: errors.New("e1")}},
+				},
+			},
+			mock.HandlerHandle{
+				Instance: "0",
+				Record: mock.Record{
+					Time:    someTime,
+					Message: "m4",
+					Level:   slog.LevelWarn,
+				},
+			},
+		))
+	})
+
+	t.Run("SetLevel", func(t Test) {
+		handler := slogx.TweakHandler(slogx.Discard()).
+			WithLevel(slog.LevelWarn).
+			WithLevelRules(slogx.SetLevelIf(slog.LevelInfo, slogx.HasAttr("x"))).
+			Result()
+
+		t.Expect(handler.Enabled(ctx, slog.LevelDebug)).To(BeFalse())
+		t.Expect(handler.Enabled(ctx, slog.LevelWarn)).To(BeTrue())
+	})
+}
+
+func TestTweakHandlerAttrs(tt *testing.T) {
+	t := New(tt)
+
+	ctx := context.Background()
+	someTime := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("Static", func(t Test) {
+		cl := mock.NewCallLog()
+		resolved := 0
+		handler := slogx.TweakHandler(mock.NewHandler(cl)).
+			WithStaticAttrs(
+				slog.String("service", "s1"),
+				slog.Any("version", valuer(func() slog.Value {
+					resolved++
+
+					return slog.StringValue("v1")
+				})),
+			).
+			Result()
+
+		for range 2 {
+			t.Expect(handler.Handle(ctx, slog.NewRecord(someTime, slog.LevelInfo, "m1", 0))).ToNot(HaveOccurred())
+		}
+
+		t.Expect(resolved).To(Equal(1))
+		t.Expect(cl.Calls()...).To(Equal(
+			mock.HandlerWithAttrs{
+				Instance: "0",
+				Attrs: []mock.Attr{
+					{Key: "service", Value: "s1"},
+					{Key: "version", Value: "v1"},
+				},
+			},
+			mock.HandlerHandle{
+				Instance: "0.1",
+				Record:   mock.Record{Time: someTime, Message: "m1", Level: slog.LevelInfo},
+			},
+			mock.HandlerHandle{
+				Instance: "0.1",
+				Record:   mock.Record{Time: someTime, Message: "m1", Level: slog.LevelInfo},
+			},
+		))
+	})
+
+	t.Run("Lazy", func(t Test) {
+		var buf bytes.Buffer
+
+		evaluated := 0
+		lazy := func() slog.Value {
+			evaluated++
+
+			return slog.IntValue(evaluated)
+		}
+
+		handler := slogx.TweakHandler(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelInfo})).
+			WithLazyAttr("a", 0, lazy).
+			WithLazyAttr("b", time.Hour, lazy).
+			Result()
+
+		logger := slogx.New(handler).WithSource(false)
+		logger.Debug("m0")
+		t.Expect(evaluated).To(Equal(0))
+
+		logger.Info("m1")
+		logger.Info("m2")
+		t.Expect(evaluated).To(Equal(3))
+		t.Expect(buf.String()).To(Equal(strings.Join([]string{
+			"time=" + timeOf(buf.String(), 0) + " level=INFO msg=m1 a=1 b=2",
+			"time=" + timeOf(buf.String(), 1) + " level=INFO msg=m2 a=3 b=2",
+			"",
+		}, "\n")))
+	})
+}
+
+type valuer func() slog.Value
+
+func (v valuer) LogValue() slog.Value {
+	return v()
+}
+
+func timeOf(output string, line int) string {
+	return strings.TrimPrefix(strings.Fields(strings.Split(output, "\n")[line])[0], "time=")
+}
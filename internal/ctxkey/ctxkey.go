@@ -0,0 +1,5 @@
+// Package ctxkey provides context keys shared between the packages of the module.
+package ctxkey
+
+// Logger is the key for a logger stored in a context, its address should be used as a key.
+var Logger int
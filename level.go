@@ -0,0 +1,178 @@
+package slogx
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Additional levels having registered names, see [RegisterLevel].
+const (
+	LevelTrace    slog.Level = -8
+	LevelNotice   slog.Level = 2
+	LevelCritical slog.Level = 12
+	LevelFatal    slog.Level = 16
+)
+
+// RegisterLevel registers the display name of the level.
+// Registered names are used by [LevelName], [ParseLevel], [ReplaceLevelAttr] and handlers provided by slogx.
+// Names of the standard levels and of [LevelTrace], [LevelNotice], [LevelCritical] and [LevelFatal] are registered by default.
+// Registering a new name for an already registered level replaces it.
+func RegisterLevel(level slog.Level, name string) {
+	levels.mu.Lock()
+	defer levels.mu.Unlock()
+
+	if old, ok := levels.names[level]; ok {
+		delete(levels.values, strings.ToUpper(old))
+	}
+
+	levels.names[level] = name
+	levels.values[strings.ToUpper(name)] = level
+	levels.sort()
+}
+
+// LevelName returns the display name of the level.
+// If the level has no registered name, the name is built like [slog.Level.String] does,
+// using the name of the nearest registered level and an offset, for example "NOTICE+1".
+func LevelName(level slog.Level) string {
+	levels.mu.RLock()
+	defer levels.mu.RUnlock()
+
+	if name, ok := levels.names[level]; ok {
+		return name
+	}
+
+	if len(levels.sorted) == 0 {
+		return level.String()
+	}
+
+	i, _ := slices.BinarySearch(levels.sorted, level)
+	if i != 0 {
+		i--
+	}
+
+	base := levels.sorted[i]
+
+	return fmt.Sprintf("%s%+d", levels.names[base], level-base)
+}
+
+// ParseLevel parses a level from a string in a case-insensitive manner.
+// It accepts registered level names optionally followed by an offset, for example "notice" or "TRACE+2",
+// and integer level values, for example "-4".
+func ParseLevel(s string) (slog.Level, error) {
+	if n, err := strconv.Atoi(s); err == nil {
+		return slog.Level(n), nil
+	}
+
+	name, offset := s, 0
+	if i := strings.IndexAny(s, "+-"); i > 0 {
+		n, err := strconv.Atoi(s[i:])
+		if err != nil {
+			return 0, fmt.Errorf("slogx: invalid level %q: %w", s, err)
+		}
+
+		name, offset = s[:i], n
+	}
+
+	levels.mu.RLock()
+	defer levels.mu.RUnlock()
+
+	level, ok := levels.values[strings.ToUpper(name)]
+	if !ok {
+		return 0, fmt.Errorf("slogx: unknown level %q", s)
+	}
+
+	return level + slog.Level(offset), nil
+}
+
+// ReplaceLevelAttr can be used as [slog.HandlerOptions.ReplaceAttr] or a part of it
+// to render levels using their registered names, see [LevelName].
+func ReplaceLevelAttr(groups []string, attr slog.Attr) slog.Attr {
+	if len(groups) == 0 && attr.Key == slog.LevelKey {
+		if level, ok := attr.Value.Any().(slog.Level); ok {
+			attr.Value = slog.StringValue(LevelName(level))
+		}
+	}
+
+	return attr
+}
+
+// ---
+
+// RegisterExitHook registers a function to be called before the program exits using [Exit]
+// or any of the Fatal logging functions.
+// Hooks are called in the reverse order of registration.
+func RegisterExitHook(hook func()) {
+	exitHooks.mu.Lock()
+	defer exitHooks.mu.Unlock()
+
+	exitHooks.hooks = append(exitHooks.hooks, hook)
+}
+
+// Exit flushes the default logger using [Shutdown], runs the hooks registered using [RegisterExitHook]
+// and terminates the program with the given status code.
+func Exit(code int) {
+	_ = Shutdown(context.Background())
+
+	exitHooks.mu.Lock()
+	hooks := slices.Clone(exitHooks.hooks)
+	exitHooks.mu.Unlock()
+
+	for i := len(hooks) - 1; i >= 0; i-- {
+		hooks[i]()
+	}
+
+	os.Exit(code)
+}
+
+// ---
+
+var levels = func() *levelRegistry {
+	r := &levelRegistry{
+		names:  make(map[slog.Level]string),
+		values: make(map[string]slog.Level),
+	}
+
+	for _, level := range []slog.Level{slog.LevelDebug, slog.LevelInfo, slog.LevelWarn, slog.LevelError} {
+		r.names[level] = level.String()
+	}
+
+	r.names[LevelTrace] = "TRACE"
+	r.names[LevelNotice] = "NOTICE"
+	r.names[LevelCritical] = "CRITICAL"
+	r.names[LevelFatal] = "FATAL"
+
+	for level, name := range r.names {
+		r.values[name] = level
+	}
+
+	r.sort()
+
+	return r
+}()
+
+type levelRegistry struct {
+	mu     sync.RWMutex
+	names  map[slog.Level]string
+	values map[string]slog.Level
+	sorted []slog.Level
+}
+
+func (r *levelRegistry) sort() {
+	r.sorted = r.sorted[:0]
+	for level := range r.names {
+		r.sorted = append(r.sorted, level)
+	}
+
+	slices.Sort(r.sorted)
+}
+
+var exitHooks struct {
+	mu    sync.Mutex
+	hooks []func()
+}
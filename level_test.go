@@ -0,0 +1,143 @@
+package slogx_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+
+	. "github.com/pamburus/go-tst/tst"
+	"github.com/pamburus/slogx"
+	"github.com/pamburus/slogx/internal/mock"
+)
+
+func TestLevel(tt *testing.T) {
+	t := New(tt)
+
+	t.Run("Name", func(t Test) {
+		t.Expect(slogx.LevelName(slogx.LevelTrace)).To(Equal("TRACE"))
+		t.Expect(slogx.LevelName(slog.LevelDebug)).To(Equal("DEBUG"))
+		t.Expect(slogx.LevelName(slogx.LevelNotice)).To(Equal("NOTICE"))
+		t.Expect(slogx.LevelName(slogx.LevelNotice + 1)).To(Equal("NOTICE+1"))
+		t.Expect(slogx.LevelName(slogx.LevelCritical)).To(Equal("CRITICAL"))
+		t.Expect(slogx.LevelName(slogx.LevelFatal + 4)).To(Equal("FATAL+4"))
+		t.Expect(slogx.LevelName(slogx.LevelTrace - 2)).To(Equal("TRACE-2"))
+	})
+
+	t.Run("Parse", func(t Test) {
+		for _, tc := range []struct {
+			s     string
+			level slog.Level
+		}{
+			{"trace", slogx.LevelTrace},
+			{"INFO", slog.LevelInfo},
+			{"Notice", slogx.LevelNotice},
+			{"critical", slogx.LevelCritical},
+			{"fatal", slogx.LevelFatal},
+			{"debug+1", slog.LevelDebug + 1},
+			{"TRACE-2", slogx.LevelTrace - 2},
+			{"-3", -3},
+		} {
+			t.Expect(slogx.ParseLevel(tc.s)).ToSucceed().AndResult().To(Equal(tc.level))
+		}
+
+		t.Expect(slogx.ParseLevel("verbose")).ToFail()
+		t.Expect(slogx.ParseLevel("info+x")).ToFail()
+	})
+
+	t.Run("Register", func(t Test) {
+		slogx.RegisterLevel(100, "Audit")
+		t.Expect(slogx.LevelName(100)).To(Equal("Audit"))
+		t.Expect(slogx.LevelName(101)).To(Equal("Audit+1"))
+		t.Expect(slogx.ParseLevel("audit")).ToSucceed().AndResult().To(Equal(slog.Level(100)))
+
+		slogx.RegisterLevel(100, "Audit2")
+		t.Expect(slogx.ParseLevel("audit")).ToFail()
+		t.Expect(slogx.ParseLevel("audit2")).ToSucceed().AndResult().To(Equal(slog.Level(100)))
+	})
+
+	t.Run("ReplaceLevelAttr", func(t Test) {
+		var buf bytes.Buffer
+
+		handler := slog.NewTextHandler(&buf, &slog.HandlerOptions{
+			Level: slogx.LevelTrace,
+			ReplaceAttr: func(groups []string, attr slog.Attr) slog.Attr {
+				if len(groups) == 0 && attr.Key == slog.TimeKey {
+					return slog.Attr{}
+				}
+
+				return slogx.ReplaceLevelAttr(groups, attr)
+			},
+		})
+
+		logger := slogx.New(handler).WithSource(false)
+		logger.Trace("m1")
+		logger.Log(slogx.LevelCritical, "m2")
+
+		t.Expect(buf.String()).To(Equal("level=TRACE msg=m1\nlevel=CRITICAL msg=m2\n"))
+	})
+
+	t.Run("Trace", func(t Test) {
+		cl := mock.NewCallLog()
+		slogx.New(mock.NewHandler(cl)).WithSource(false).Trace("m1")
+
+		t.Expect(cl.Calls().WithoutTime()...).To(Equal(
+			mock.HandlerEnabled{Instance: "0", Level: slogx.LevelTrace},
+			mock.HandlerHandle{Instance: "0", Record: mock.Record{Level: slogx.LevelTrace, Message: "m1"}},
+		))
+	})
+}
+
+func TestFatal(tt *testing.T) {
+	t := New(tt)
+
+	if os.Getenv("SLOGX_TEST_FATAL") == "1" {
+		slogx.RegisterExitHook(func() { fmt.Println("hook1") })
+		slogx.RegisterExitHook(func() { fmt.Println("hook2") })
+
+		handler := slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{
+			ReplaceAttr: func(groups []string, attr slog.Attr) slog.Attr {
+				if len(groups) == 0 && attr.Key == slog.TimeKey {
+					return slog.Attr{}
+				}
+
+				return slogx.ReplaceLevelAttr(groups, attr)
+			},
+		})
+		slog.SetDefault(slog.New(flushReporter{handler}))
+		slogx.New(handler).WithSource(false).Fatal("m1")
+
+		return
+	}
+
+	cmd := exec.Command(os.Args[0], "-test.run=^TestFatal$")
+	cmd.Env = append(os.Environ(), "SLOGX_TEST_FATAL=1")
+	output, err := cmd.Output()
+
+	var exitErr *exec.ExitError
+	t.Expect(errors.As(err, &exitErr)).To(BeTrue())
+	t.Expect(exitErr.ExitCode()).To(Equal(1))
+	t.Expect(strings.Split(string(output), "\n")[:4]).To(Equal([]string{
+		"level=FATAL msg=m1",
+		"flushed",
+		"hook2",
+		"hook1",
+	}))
+}
+
+// ---
+
+type flushReporter struct {
+	slog.Handler
+}
+
+func (h flushReporter) Flush(context.Context) error {
+	fmt.Println("flushed")
+
+	return nil
+}
@@ -0,0 +1,93 @@
+package slogx
+
+import (
+	"context"
+	"log/slog"
+	"regexp"
+)
+
+// LevelRule is a rule for [TweakHandlerBuilder.WithLevelRules] that changes the level of matching records.
+type LevelRule struct {
+	level slog.Level
+	mode  levelRuleMode
+	match RecordMatcher
+}
+
+// SetLevelIf returns a [LevelRule] that sets the given level for records matching the condition.
+func SetLevelIf(level slog.Level, match RecordMatcher) LevelRule {
+	return LevelRule{level, levelRuleSet, match}
+}
+
+// PromoteLevelIf returns a [LevelRule] that raises the level of records matching the condition
+// up to the given level if it is lower.
+func PromoteLevelIf(level slog.Level, match RecordMatcher) LevelRule {
+	return LevelRule{level, levelRulePromote, match}
+}
+
+// DemoteLevelIf returns a [LevelRule] that lowers the level of records matching the condition
+// down to the given level if it is higher.
+func DemoteLevelIf(level slog.Level, match RecordMatcher) LevelRule {
+	return LevelRule{level, levelRuleDemote, match}
+}
+
+func (r LevelRule) mayRaise(level slog.Level) bool {
+	return r.mode != levelRuleDemote && r.level > level
+}
+
+func (r LevelRule) apply(ctx context.Context, record slog.Record) slog.Level {
+	switch r.mode {
+	case levelRulePromote:
+		if record.Level >= r.level {
+			return record.Level
+		}
+	case levelRuleDemote:
+		if record.Level <= r.level {
+			return record.Level
+		}
+	}
+
+	if !r.match(ctx, record) {
+		return record.Level
+	}
+
+	return r.level
+}
+
+// ---
+
+// RecordMatcher reports whether a record matches a condition.
+type RecordMatcher func(context.Context, slog.Record) bool
+
+// HasAttr returns a [RecordMatcher] that matches records having a top-level attribute with the given key,
+// for example, [ErrorKey].
+// Note that it does not see the attributes added to the handler using [slog.Handler.WithAttrs].
+func HasAttr(key string) RecordMatcher {
+	return func(_ context.Context, record slog.Record) bool {
+		found := false
+
+		record.Attrs(func(attr slog.Attr) bool {
+			found = attr.Key == key
+
+			return !found
+		})
+
+		return found
+	}
+}
+
+// MessageMatches returns a [RecordMatcher] that matches records having a message matching the pattern.
+func MessageMatches(pattern *regexp.Regexp) RecordMatcher {
+	return func(_ context.Context, record slog.Record) bool {
+		return pattern.MatchString(record.Message)
+	}
+}
+
+// ---
+
+type levelRuleMode int
+
+const (
+	levelRuleSet levelRuleMode = iota
+	levelRulePromote
+	levelRuleDemote
+)
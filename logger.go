@@ -8,7 +8,6 @@ import (
 	"cmp"
 	"context"
 	"log/slog"
-	"runtime"
 	"time"
 )
 
@@ -43,6 +42,11 @@ func WithGroup(group string) *Logger {
 	return Default().WithGroup(group)
 }
 
+// Trace logs a message at the trace level.
+func Trace(msg string, attrs ...slog.Attr) {
+	logAttrs(context.Background(), defaultHandler(), LevelTrace, msg, attrs)
+}
+
 // Debug logs a message at the debug level.
 func Debug(msg string, attrs ...slog.Attr) {
 	logAttrs(context.Background(), defaultHandler(), slog.LevelDebug, msg, attrs)
@@ -63,6 +67,12 @@ func Error(msg string, attrs ...slog.Attr) {
 	logAttrs(context.Background(), defaultHandler(), slog.LevelError, msg, attrs)
 }
 
+// Fatal logs a message at the fatal level and terminates the program using [Exit] with status code 1.
+func Fatal(msg string, attrs ...slog.Attr) {
+	logAttrs(context.Background(), defaultHandler(), LevelFatal, msg, attrs)
+	Exit(1)
+}
+
 // Log logs a message at the given level.
 func Log(level slog.Level, msg string, attrs ...slog.Attr) {
 	logAttrs(context.Background(), defaultHandler(), level, msg, attrs)
@@ -83,6 +93,11 @@ func (l *Logger) Handler() slog.Handler {
 	return l.handlerForExport()
 }
 
+// Flush flushes the logger's handler if it implements [Flusher].
+func (l *Logger) Flush(ctx context.Context) error {
+	return Flush(ctx, l.handler)
+}
+
 // SlogLogger returns a new [slog.Logger] that logs to the associated handler.
 func (l *Logger) SlogLogger() *slog.Logger {
 	return slog.New(l.handler)
@@ -131,14 +146,37 @@ func (l *Logger) WithGroup(group string) *Logger {
 
 // WithSource returns a new [Logger] that includes the source file and line in the log record if [enabled] is true.
 func (l *Logger) WithSource(enabled bool) *Logger {
-	if l.src != enabled {
+	if l.src != enabled || l.srcLevel != nil {
 		l = l.clone()
 		l.src = enabled
+		l.srcLevel = nil
 	}
 
 	return l
 }
 
+// WithSourceLevel returns a new [Logger] that includes the source file and line in the log record
+// only if the record's level is greater than or equal to the given level.
+// This allows to avoid the cost of capturing the source location for records at lower levels.
+// A subsequent [Logger.WithSource] call overrides it.
+func (l *Logger) WithSourceLevel(level slog.Leveler) *Logger {
+	l = l.clone()
+	l.src = true
+	l.srcLevel = level
+
+	return l
+}
+
+// Trace logs a message at the trace level.
+func (l *Logger) Trace(msg string, attrs ...slog.Attr) {
+	l.log(context.Background(), LevelTrace, msg, attrs, 0)
+}
+
+// TraceContext logs a message at the trace level with the given context.
+func (l *Logger) TraceContext(ctx context.Context, msg string, attrs ...slog.Attr) {
+	l.log(ctx, LevelTrace, msg, attrs, 0)
+}
+
 // Debug logs a message at the debug level.
 func (l *Logger) Debug(msg string, attrs ...slog.Attr) {
 	l.log(context.Background(), slog.LevelDebug, msg, attrs, 0)
@@ -179,6 +217,20 @@ func (l *Logger) ErrorContext(ctx context.Context, msg string, attrs ...slog.Att
 	l.log(ctx, slog.LevelError, msg, attrs, 0)
 }
 
+// Fatal logs a message at the fatal level, flushes the logger's handler
+// and terminates the program using [Exit] with status code 1.
+func (l *Logger) Fatal(msg string, attrs ...slog.Attr) {
+	l.log(context.Background(), LevelFatal, msg, attrs, 0)
+	l.exit(context.Background())
+}
+
+// FatalContext logs a message at the fatal level with the given context, flushes the logger's handler
+// and terminates the program using [Exit] with status code 1.
+func (l *Logger) FatalContext(ctx context.Context, msg string, attrs ...slog.Attr) {
+	l.log(ctx, LevelFatal, msg, attrs, 0)
+	l.exit(ctx)
+}
+
 // Log logs a message at the given level.
 func (l *Logger) Log(level slog.Level, msg string, attrs ...slog.Attr) {
 	l.log(context.Background(), level, msg, attrs, 0)
@@ -194,6 +246,17 @@ func (l *Logger) LogAttrs(ctx context.Context, level slog.Level, msg string, att
 	l.log(ctx, level, msg, attrs, 0)
 }
 
+// LogWithCallerSkip logs a message at the given level with additional skipping of the specified amount of call stack frames.
+func (l *Logger) LogWithCallerSkip(ctx context.Context, skip int, level slog.Level, msg string, attrs ...slog.Attr) {
+	l.log(ctx, level, msg, attrs, skip)
+}
+
+// LogWithPC logs a message at the given level using the given program counter as the source location.
+// It is useful for adapters that determine the source location on their own.
+func (l *Logger) LogWithPC(ctx context.Context, pc uintptr, level slog.Level, msg string, attrs ...slog.Attr) {
+	l.logPC(ctx, level, msg, attrs, pc)
+}
+
 // LongTerm returns a new [Logger] with the attributes applied to the handler.
 func (l *Logger) LongTerm() *Logger {
 	if l.attrs.Len() != 0 {
@@ -225,6 +288,11 @@ func (l *ContextLogger) Handler() slog.Handler {
 	return l.handlerForExport()
 }
 
+// Flush flushes the associated handler if it implements [Flusher].
+func (l *ContextLogger) Flush(ctx context.Context) error {
+	return Flush(ctx, l.handler)
+}
+
 // SlogLogger returns a new [slog.Logger] that logs to the associated handler.
 func (l *ContextLogger) SlogLogger() *slog.Logger {
 	return slog.New(l.handler)
@@ -268,14 +336,32 @@ func (l *ContextLogger) WithGroup(group string) *ContextLogger {
 
 // WithSource returns a new [ContextLogger] that includes the source file and line in the log record if [enabled] is true.
 func (l *ContextLogger) WithSource(enabled bool) *ContextLogger {
-	if l.src != enabled {
+	if l.src != enabled || l.srcLevel != nil {
 		l = l.clone()
 		l.src = enabled
+		l.srcLevel = nil
 	}
 
 	return l
 }
 
+// WithSourceLevel returns a new [ContextLogger] that includes the source file and line in the log record
+// only if the record's level is greater than or equal to the given level.
+// This allows to avoid the cost of capturing the source location for records at lower levels.
+// A subsequent [ContextLogger.WithSource] call overrides it.
+func (l *ContextLogger) WithSourceLevel(level slog.Leveler) *ContextLogger {
+	l = l.clone()
+	l.src = true
+	l.srcLevel = level
+
+	return l
+}
+
+// Trace logs a message at the trace level.
+func (l *ContextLogger) Trace(ctx context.Context, msg string, attrs ...slog.Attr) {
+	l.log(ctx, LevelTrace, msg, attrs, 0)
+}
+
 // Debug logs a message at the debug level.
 func (l *ContextLogger) Debug(ctx context.Context, msg string, attrs ...slog.Attr) {
 	l.log(ctx, slog.LevelDebug, msg, attrs, 0)
@@ -296,6 +382,13 @@ func (l *ContextLogger) Error(ctx context.Context, msg string, attrs ...slog.Att
 	l.log(ctx, slog.LevelError, msg, attrs, 0)
 }
 
+// Fatal logs a message at the fatal level, flushes the logger's handler
+// and terminates the program using [Exit] with status code 1.
+func (l *ContextLogger) Fatal(ctx context.Context, msg string, attrs ...slog.Attr) {
+	l.log(ctx, LevelFatal, msg, attrs, 0)
+	l.exit(ctx)
+}
+
 // Log logs a message at the given level.
 func (l *ContextLogger) Log(ctx context.Context, level slog.Level, msg string, attrs ...slog.Attr) {
 	l.log(ctx, level, msg, attrs, 0)
@@ -311,6 +404,12 @@ func (l *ContextLogger) LogWithCallerSkip(ctx context.Context, skip int, level s
 	l.log(ctx, level, msg, attrs, skip)
 }
 
+// LogWithPC logs a message at the given level using the given program counter as the source location.
+// It is useful for adapters that determine the source location on their own.
+func (l *ContextLogger) LogWithPC(ctx context.Context, pc uintptr, level slog.Level, msg string, attrs ...slog.Attr) {
+	l.logPC(ctx, level, msg, attrs, pc)
+}
+
 // LongTerm returns a new [Logger] with the attributes applied to the handler.
 func (l *ContextLogger) LongTerm() *ContextLogger {
 	if l.attrs.Len() != 0 {
@@ -334,9 +433,10 @@ func defaultHandler() slog.Handler {
 // ---
 
 type commonLogger struct {
-	handler slog.Handler
-	src     bool
-	attrs   AttrPack
+	handler  slog.Handler
+	src      bool
+	srcLevel slog.Leveler
+	attrs    AttrPack
 }
 
 func (l *commonLogger) handlerForExport() slog.Handler {
@@ -376,12 +476,46 @@ func (l *commonLogger) log(ctx context.Context, level slog.Level, msg string, at
 		return
 	}
 
-	var pcs [1]uintptr
-	if l.src {
-		runtime.Callers(skip+3, pcs[:])
+	l.logEnabled(ctx, level, msg, attrs, skip+1)
+}
+
+// logEnabled logs a record at the level already known to be enabled.
+func (l *commonLogger) logEnabled(ctx context.Context, level slog.Level, msg string, attrs []slog.Attr, skip int) {
+	var pc uintptr
+	if l.sourceEnabled(level) {
+		pc = callerPC(skip + 3)
+	}
+
+	l.handle(ctx, level, msg, attrs, pc)
+}
+
+func (l *commonLogger) logPC(ctx context.Context, level slog.Level, msg string, attrs []slog.Attr, pc uintptr) {
+	ctx = cmp.Or(ctx, context.Background())
+
+	if !l.handler.Enabled(ctx, level) {
+		return
+	}
+
+	if !l.sourceEnabled(level) {
+		pc = 0
 	}
 
-	r := slog.NewRecord(time.Now(), level, msg, pcs[0])
+	l.handle(ctx, level, msg, attrs, pc)
+}
+
+func (l *commonLogger) sourceEnabled(level slog.Level) bool {
+	return l.src && (l.srcLevel == nil || level >= l.srcLevel.Level())
+}
+
+func (l *commonLogger) handle(ctx context.Context, level slog.Level, msg string, attrs []slog.Attr, pc uintptr) {
+	r := l.newRecord(time.Now(), level, msg, pc)
+	r.AddAttrs(attrs...)
+
+	_ = l.handler.Handle(ctx, r)
+}
+
+func (l *commonLogger) newRecord(t time.Time, level slog.Level, msg string, pc uintptr) slog.Record {
+	r := slog.NewRecord(t, level, msg, pc)
 
 	if l.attrs.Len() != 0 {
 		l.attrs.Enumerate(func(attr slog.Attr) bool {
@@ -391,9 +525,12 @@ func (l *commonLogger) log(ctx context.Context, level slog.Level, msg string, at
 		})
 	}
 
-	r.AddAttrs(attrs...)
+	return r
+}
 
-	_ = l.handler.Handle(ctx, r)
+func (l *commonLogger) exit(ctx context.Context) {
+	_ = Flush(ctx, l.handler)
+	Exit(1)
 }
 
 // ---
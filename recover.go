@@ -0,0 +1,172 @@
+package slogx
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"fmt"
+	"log/slog"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// Recover recovers from a panic, if any, and logs it using the logger.
+// It must be called directly by a deferred function, for example:
+//
+//	defer logger.Recover(nil)
+//
+// See [RecoverOptions] for the available options, nil options mean default options.
+func (l *Logger) Recover(options *RecoverOptions) {
+	if value := recover(); value != nil {
+		l.handlePanic(context.Background(), value, options)
+	}
+}
+
+// Recover recovers from a panic, if any, and logs it using the logger.
+// It must be called directly by a deferred function, for example:
+//
+//	defer logger.Recover(ctx, nil)
+//
+// See [RecoverOptions] for the available options, nil options mean default options.
+func (l *ContextLogger) Recover(ctx context.Context, options *RecoverOptions) {
+	if value := recover(); value != nil {
+		l.handlePanic(ctx, value, options)
+	}
+}
+
+// HandlePanic logs a panic value that has just been recovered and handles it according to the options.
+// It is intended for building custom recovery helpers like [github.com/pamburus/slogx/slogc.Recover]
+// and must be called by the deferred function that recovered the panic.
+func (l *ContextLogger) HandlePanic(ctx context.Context, value any, options *RecoverOptions) {
+	l.handlePanic(ctx, value, options)
+}
+
+// ---
+
+// RecoverOptions contains options for panic recovery.
+type RecoverOptions struct {
+	// Level is the level of the log record, [slog.LevelError] is used if it is nil.
+	Level slog.Leveler
+	// Message is the message of the log record, "panic" is used if it is empty.
+	Message string
+	// RePanic specifies whether to panic again with the same value after logging.
+	RePanic bool
+	// Err, if not nil, receives a [*PanicError] describing the recovered panic.
+	Err *error
+}
+
+// ---
+
+// PanicError is an error describing a recovered panic.
+type PanicError struct {
+	// Value is the value passed to panic.
+	Value any
+	// Stack is the stack trace of the panicking goroutine starting from the panicking function.
+	Stack []uintptr
+	// Goroutine is the identifier of the panicking goroutine.
+	Goroutine int64
+}
+
+// Error returns the error message.
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("panic: %v", e.Value)
+}
+
+// Unwrap returns the panic value if it is an error.
+func (e *PanicError) Unwrap() error {
+	err, _ := e.Value.(error)
+
+	return err
+}
+
+// StackTrace returns the stack trace of the panicking goroutine.
+func (e *PanicError) StackTrace() []uintptr {
+	return e.Stack
+}
+
+// ---
+
+// Keys of the attributes added to the log record describing a recovered panic.
+const (
+	PanicKey     = "panic"
+	StackKey     = "stack"
+	GoroutineKey = "goroutine"
+)
+
+// ---
+
+func (l *commonLogger) handlePanic(ctx context.Context, value any, options *RecoverOptions) {
+	if options == nil {
+		options = &RecoverOptions{}
+	}
+
+	stack := panicStack()
+	pe := &PanicError{Value: value, Stack: stack, Goroutine: currentGoroutine()}
+
+	level := slog.LevelError
+	if options.Level != nil {
+		level = options.Level.Level()
+	}
+
+	l.logPC(ctx, level, cmp.Or(options.Message, "panic"), []slog.Attr{
+		slog.Any(PanicKey, value),
+		slog.Any(StackKey, formatStackTrace(stack)),
+		slog.Int64(GoroutineKey, pe.Goroutine),
+	}, panicPC(stack))
+
+	if options.Err != nil {
+		*options.Err = pe
+	}
+
+	if options.RePanic {
+		panic(value)
+	}
+}
+
+// panicStack returns the stack of the panicking goroutine starting from the function that caused the panic.
+func panicStack() []uintptr {
+	pcs := make([]uintptr, 64)
+	pcs = pcs[:runtime.Callers(2, pcs)]
+
+	for i, pc := range pcs {
+		if fn := runtime.FuncForPC(pc - 1); fn != nil && fn.Name() == "runtime.gopanic" {
+			pcs = pcs[i+1:]
+
+			break
+		}
+	}
+
+	for len(pcs) != 0 {
+		if fn := runtime.FuncForPC(pcs[0] - 1); fn != nil && !strings.HasPrefix(fn.Name(), "runtime.") {
+			break
+		}
+
+		pcs = pcs[1:]
+	}
+
+	return pcs
+}
+
+func panicPC(stack []uintptr) uintptr {
+	if len(stack) == 0 {
+		return 0
+	}
+
+	return stack[0]
+}
+
+func currentGoroutine() int64 {
+	var buf [64]byte
+
+	line := buf[:runtime.Stack(buf[:], false)]
+	line = bytes.TrimPrefix(line, []byte("goroutine "))
+
+	if i := bytes.IndexByte(line, ' '); i >= 0 {
+		if id, err := strconv.ParseInt(string(line[:i]), 10, 64); err == nil {
+			return id
+		}
+	}
+
+	return 0
+}
@@ -0,0 +1,131 @@
+package slogx_test
+
+import (
+	"errors"
+	"log/slog"
+	"runtime"
+	"testing"
+
+	. "github.com/pamburus/go-tst/tst"
+	"github.com/pamburus/slogx"
+	"github.com/pamburus/slogx/internal/mock"
+)
+
+func TestRecover(tt *testing.T) {
+	t := New(tt)
+
+	setup := func() (*mock.CallLog, *slogx.Logger) {
+		cl := mock.NewCallLog()
+
+		return cl, slogx.New(mock.NewHandler(cl))
+	}
+
+	lastRecord := func(cl *mock.CallLog) mock.Record {
+		calls := cl.Calls().WithoutTime()
+
+		return calls[len(calls)-1].(mock.HandlerHandle).Record
+	}
+
+	t.Run("Default", func(t Test) {
+		cl, logger := setup()
+
+		func() {
+			defer logger.Recover(nil)
+
+			panicWith("p1")
+		}()
+
+		record := lastRecord(cl)
+		t.Expect(record.Level).To(Equal(slog.LevelError))
+		t.Expect(record.Message).To(Equal("panic"))
+		t.Expect(functionOf(record.PC)).To(Equal("github.com/pamburus/slogx_test.panicWith"))
+		t.Expect(record.Attrs).To(HaveLen(3))
+		t.Expect(record.Attrs[0]).To(Equal(mock.Attr{Key: slogx.PanicKey, Value: "p1"}))
+		t.Expect(record.Attrs[1].Key).To(Equal(slogx.StackKey))
+		t.Expect(record.Attrs[2].Key).To(Equal(slogx.GoroutineKey))
+		t.Expect(record.Attrs[2].Value).To(BeGreaterThan(int64(0)))
+	})
+
+	t.Run("RuntimeError", func(t Test) {
+		cl, logger := setup()
+
+		func() {
+			defer logger.Recover(nil)
+
+			dereference(nil)
+		}()
+
+		t.Expect(functionOf(lastRecord(cl).PC)).To(Equal("github.com/pamburus/slogx_test.dereference"))
+	})
+
+	t.Run("Options", func(t Test) {
+		cl, logger := setup()
+
+		var err error
+
+		func() {
+			defer logger.Recover(&slogx.RecoverOptions{
+				Level:   slog.LevelWarn,
+				Message: "recovered",
+				Err:     &err,
+			})
+
+			panicWith(errors.New("e1"))
+		}()
+
+		record := lastRecord(cl)
+		t.Expect(record.Level).To(Equal(slog.LevelWarn))
+		t.Expect(record.Message).To(Equal("recovered"))
+
+		var pe *slogx.PanicError
+		t.Expect(errors.As(err, &pe)).To(BeTrue())
+		t.Expect(err.Error()).To(Equal("panic: e1"))
+		t.Expect(errors.Unwrap(err)).To(Equal(errors.New("e1")))
+		t.Expect(functionOf(pe.StackTrace()[0])).To(Equal("github.com/pamburus/slogx_test.panicWith"))
+	})
+
+	t.Run("RePanic", func(t Test) {
+		cl, logger := setup()
+
+		value := func() (value any) {
+			defer func() {
+				value = recover()
+			}()
+
+			defer logger.Recover(&slogx.RecoverOptions{RePanic: true})
+
+			panicWith("p1")
+
+			return nil
+		}()
+
+		t.Expect(value).To(Equal("p1"))
+		t.Expect(lastRecord(cl).Attrs[0]).To(Equal(mock.Attr{Key: slogx.PanicKey, Value: "p1"}))
+	})
+
+	t.Run("NoPanic", func(t Test) {
+		cl, logger := setup()
+
+		func() {
+			defer logger.Recover(nil)
+		}()
+
+		t.Expect(cl.Calls()).To(BeZero())
+	})
+}
+
+//go:noinline
+func panicWith(value any) {
+	panic(value)
+}
+
+//go:noinline
+func dereference(p *int) int {
+	return *p
+}
+
+func functionOf(pc uintptr) string {
+	frame, _ := runtime.CallersFrames([]uintptr{pc}).Next()
+
+	return frame.Function
+}
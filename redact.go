@@ -0,0 +1,163 @@
+package slogx
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"log/slog"
+	"regexp"
+	"slices"
+)
+
+// Redactable is implemented by types that know how to represent themselves in a redacted form.
+// Handlers built using [TweakHandlerBuilder.WithRedaction] replace values of such types
+// with the result of [Redactable.Redacted] method.
+type Redactable interface {
+	Redacted() slog.Value
+}
+
+// Redactor returns a redacted replacement for the given sensitive string.
+type Redactor func(string) string
+
+// MaskRedactor returns a [Redactor] that replaces any value with the given mask.
+func MaskRedactor(mask string) Redactor {
+	return func(string) string {
+		return mask
+	}
+}
+
+// HashRedactor returns a [Redactor] that replaces any value with a truncated salted SHA-256 hash of it.
+// It allows to correlate equal values across log records without disclosing them.
+func HashRedactor(salt string) Redactor {
+	return func(value string) string {
+		hash := sha256.Sum256([]byte(salt + value))
+
+		return "sha256:" + hex.EncodeToString(hash[:8])
+	}
+}
+
+// ---
+
+// RedactionRule is a rule for [TweakHandlerBuilder.WithRedaction].
+type RedactionRule struct {
+	keys     []string
+	path     []string
+	pattern  *regexp.Regexp
+	redactor Redactor
+}
+
+// RedactKeys returns a [RedactionRule] that redacts values of attributes with any of the given keys at any depth.
+func RedactKeys(redactor Redactor, keys ...string) RedactionRule {
+	return RedactionRule{keys: slices.Clone(keys), redactor: redactor}
+}
+
+// RedactPath returns a [RedactionRule] that redacts the value of the attribute having the given key path,
+// where the last element is the attribute key and all preceding elements are the keys of the enclosing groups,
+// including the groups opened using [slog.Handler.WithGroup].
+func RedactPath(redactor Redactor, path ...string) RedactionRule {
+	return RedactionRule{path: slices.Clone(path), redactor: redactor}
+}
+
+// RedactPattern returns a [RedactionRule] that redacts all matches of the given pattern in string values.
+func RedactPattern(redactor Redactor, pattern *regexp.Regexp) RedactionRule {
+	return RedactionRule{pattern: pattern, redactor: redactor}
+}
+
+// Commonly used patterns for [RedactPattern].
+var (
+	// EmailPattern matches email addresses.
+	EmailPattern = regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`)
+	// CardNumberPattern matches payment card numbers optionally separated by spaces or dashes.
+	CardNumberPattern = regexp.MustCompile(`\b\d(?:[ -]?\d){12,18}\b`)
+	// BearerTokenPattern matches bearer tokens as they appear in the Authorization header.
+	BearerTokenPattern = regexp.MustCompile(`(?i)\bbearer\s+[A-Za-z0-9\-._~+/]+=*`)
+)
+
+// ---
+
+func newRedaction(rules []RedactionRule) *redaction {
+	r := &redaction{keys: make(map[string]Redactor)}
+
+	for _, rule := range rules {
+		for _, key := range rule.keys {
+			r.keys[key] = rule.redactor
+		}
+
+		if len(rule.path) != 0 {
+			r.paths = append(r.paths, rule)
+		}
+
+		if rule.pattern != nil {
+			r.patterns = append(r.patterns, rule)
+		}
+	}
+
+	return r
+}
+
+type redaction struct {
+	keys     map[string]Redactor
+	paths    []RedactionRule
+	patterns []RedactionRule
+}
+
+func (r *redaction) stage() attrStage {
+	return attrStage{replace: r.replace, resolve: resolveRedactable}
+}
+
+func (r *redaction) replace(groups []string, attr slog.Attr) slog.Attr {
+	if redactor := r.match(groups, attr.Key); redactor != nil {
+		attr.Value = slog.StringValue(redactor(attr.Value.Resolve().String()))
+
+		return attr
+	}
+
+	if len(r.patterns) != 0 {
+		attr.Value = attr.Value.Resolve()
+		if attr.Value.Kind() == slog.KindString {
+			value := attr.Value.String()
+			for _, rule := range r.patterns {
+				value = rule.pattern.ReplaceAllStringFunc(value, rule.redactor)
+			}
+
+			attr.Value = slog.StringValue(value)
+		}
+	}
+
+	return attr
+}
+
+func (r *redaction) match(groups []string, key string) Redactor {
+	if redactor, ok := r.keys[key]; ok {
+		return redactor
+	}
+
+	for _, rule := range r.paths {
+		n := len(rule.path) - 1
+		if n == len(groups) && rule.path[n] == key && slices.Equal(rule.path[:n], groups) {
+			return rule.redactor
+		}
+	}
+
+	return nil
+}
+
+// resolveRedactable replaces values of types implementing [Redactable] with their redacted representation
+// before they are resolved, so that [slog.LogValuer] implementations cannot bypass the redaction.
+func resolveRedactable(value slog.Value) slog.Value {
+	if redactable, ok := redactableOf(value); ok {
+		value = redactable.Redacted()
+	}
+
+	return value.Resolve()
+}
+
+func redactableOf(value slog.Value) (Redactable, bool) {
+	switch value.Kind() {
+	case slog.KindAny, slog.KindLogValuer:
+		redactable, ok := value.Any().(Redactable)
+
+		return redactable, ok
+	default:
+		return nil, false
+	}
+}
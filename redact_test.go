@@ -0,0 +1,165 @@
+package slogx_test
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	. "github.com/pamburus/go-tst/tst"
+	"github.com/pamburus/slogx"
+	"github.com/pamburus/slogx/internal/mock"
+)
+
+func TestRedaction(tt *testing.T) {
+	t := New(tt)
+
+	someTime := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	mask := slogx.MaskRedactor("***")
+
+	t.Run("Keys", func(t Test) {
+		cl := mock.NewCallLog()
+		handler := slogx.TweakHandler(mock.NewHandler(cl)).
+			WithRedaction(slogx.RedactKeys(mask, "password", "token")).
+			Result()
+
+		handler = handler.WithAttrs([]slog.Attr{slog.String("token", "t1"), slog.String("user", "u1")})
+		record := slog.NewRecord(someTime, slog.LevelInfo, "m1", 0)
+		record.AddAttrs(
+			slog.Group("auth", slog.String("password", "p1")),
+			slog.Int("n", 1),
+		)
+
+		t.Expect(handler.Handle(context.Background(), record)).ToNot(HaveOccurred())
+		t.Expect(cl.Calls()...).To(Equal(
+			mock.HandlerWithAttrs{
+				Instance: "0",
+				Attrs: []mock.Attr{
+					{Key: "token", Value: "***"},
+					{Key: "user", Value: "u1"},
+				},
+			},
+			mock.HandlerHandle{
+				Instance: "0.1",
+				Record: mock.Record{
+					Time:    someTime,
+					Message: "m1",
+					Level:   slog.LevelInfo,
+					Attrs: []mock.Attr{
+						{Key: "auth", Value: []slog.Attr{slog.String("password", "***")}},
+						{Key: "n", Value: int64(1)},
+					},
+				},
+			},
+		))
+	})
+
+	t.Run("Path", func(t Test) {
+		cl := mock.NewCallLog()
+		handler := slogx.TweakHandler(mock.NewHandler(cl)).
+			WithRedaction(slogx.RedactPath(mask, "req", "user", "email")).
+			Result()
+
+		handler = handler.WithGroup("req")
+		record := slog.NewRecord(someTime, slog.LevelInfo, "m1", 0)
+		record.AddAttrs(
+			slog.String("email", "a@b.c"),
+			slog.Group("user", slog.String("email", "a@b.c")),
+		)
+
+		t.Expect(handler.Handle(context.Background(), record)).ToNot(HaveOccurred())
+		t.Expect(cl.Calls()...).To(Equal(
+			mock.HandlerWithGroup{Instance: "0", Key: "req"},
+			mock.HandlerHandle{
+				Instance: "0.1",
+				Record: mock.Record{
+					Time:    someTime,
+					Message: "m1",
+					Level:   slog.LevelInfo,
+					Attrs: []mock.Attr{
+						{Key: "email", Value: "a@b.c"},
+						{Key: "user", Value: []slog.Attr{slog.String("email", "***")}},
+					},
+				},
+			},
+		))
+	})
+
+	t.Run("Patterns", func(t Test) {
+		cl := mock.NewCallLog()
+		handler := slogx.TweakHandler(mock.NewHandler(cl)).
+			WithRedaction(
+				slogx.RedactPattern(mask, slogx.EmailPattern),
+				slogx.RedactPattern(mask, slogx.CardNumberPattern),
+				slogx.RedactPattern(mask, slogx.BearerTokenPattern),
+			).
+			Result()
+
+		record := slog.NewRecord(someTime, slog.LevelInfo, "m1", 0)
+		record.AddAttrs(
+			slog.String("a", "contact john.doe@example.com now"),
+			slog.String("b", "card 4111 1111 1111 1111 used"),
+			slog.String("c", "Authorization: Bearer abc.DEF-123"),
+			slog.Int("d", 4111111111111111),
+		)
+
+		t.Expect(handler.Handle(context.Background(), record)).ToNot(HaveOccurred())
+		t.Expect(cl.Calls()...).To(Equal(
+			mock.HandlerHandle{
+				Instance: "0",
+				Record: mock.Record{
+					Time:    someTime,
+					Message: "m1",
+					Level:   slog.LevelInfo,
+					Attrs: []mock.Attr{
+						{Key: "a", Value: "contact *** now"},
+						{Key: "b", Value: "card *** used"},
+						{Key: "c", Value: "Authorization: ***"},
+						{Key: "d", Value: int64(4111111111111111)},
+					},
+				},
+			},
+		))
+	})
+
+	t.Run("Redactable", func(t Test) {
+		cl := mock.NewCallLog()
+		handler := slogx.TweakHandler(mock.NewHandler(cl)).
+			WithRedaction().
+			Result()
+
+		handler = handler.WithAttrs([]slog.Attr{slog.Any("s", secret("x"))})
+		record := slog.NewRecord(someTime, slog.LevelInfo, "m1", 0)
+		record.AddAttrs(slog.Any("s", secret("y")))
+
+		t.Expect(handler.Handle(context.Background(), record)).ToNot(HaveOccurred())
+		t.Expect(cl.Calls()...).To(Equal(
+			mock.HandlerWithAttrs{
+				Instance: "0",
+				Attrs:    []mock.Attr{{Key: "s", Value: "[secret]"}},
+			},
+			mock.HandlerHandle{
+				Instance: "0.1",
+				Record: mock.Record{
+					Time:    someTime,
+					Message: "m1",
+					Level:   slog.LevelInfo,
+					Attrs:   []mock.Attr{{Key: "s", Value: "[secret]"}},
+				},
+			},
+		))
+	})
+
+	t.Run("Hash", func(t Test) {
+		redactor := slogx.HashRedactor("salt")
+		t.Expect(redactor("a")).To(Equal(redactor("a")))
+		t.Expect(redactor("a")).ToNot(Equal(redactor("b")))
+		t.Expect(redactor("a")).To(HaveLen(len("sha256:") + 16))
+	})
+}
+
+type secret string
+
+func (secret) Redacted() slog.Value {
+	return slog.StringValue("[secret]")
+}
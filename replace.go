@@ -0,0 +1,90 @@
+package slogx
+
+import (
+	"log/slog"
+	"slices"
+)
+
+// AttrReplacer is a function that replaces an attribute given the list of keys of the enclosing groups.
+// See [TweakHandlerBuilder.WithAttrReplacer] for details.
+type AttrReplacer func(groups []string, attr slog.Attr) slog.Attr
+
+// RenameAttr returns an [AttrReplacer] that renames attributes with the given key at any depth.
+func RenameAttr(key, newKey string) AttrReplacer {
+	return func(_ []string, attr slog.Attr) slog.Attr {
+		if attr.Key == key {
+			attr.Key = newKey
+		}
+
+		return attr
+	}
+}
+
+// DropAttrs returns an [AttrReplacer] that drops attributes with any of the given keys at any depth.
+func DropAttrs(keys ...string) AttrReplacer {
+	keys = slices.Clone(keys)
+
+	return func(_ []string, attr slog.Attr) slog.Attr {
+		if slices.Contains(keys, attr.Key) {
+			return slog.Attr{}
+		}
+
+		return attr
+	}
+}
+
+// ReplaceAttrValue returns an [AttrReplacer] that replaces values of attributes with the given key at any depth
+// with the result of the given function.
+func ReplaceAttrValue(key string, replace func(slog.Value) slog.Value) AttrReplacer {
+	return func(_ []string, attr slog.Attr) slog.Attr {
+		if attr.Key == key {
+			attr.Value = replace(attr.Value)
+		}
+
+		return attr
+	}
+}
+
+// MoveAttrToGroup returns an [AttrReplacer] that moves attributes with the given key at any depth
+// into a nested group with the given key.
+func MoveAttrToGroup(key, group string) AttrReplacer {
+	return func(_ []string, attr slog.Attr) slog.Attr {
+		if attr.Key == key {
+			return slog.Group(group, attr)
+		}
+
+		return attr
+	}
+}
+
+// MoveAttrsOutOfGroup returns an [AttrReplacer] that moves members with any of the given keys
+// out of groups with the given key at any depth into the enclosing group.
+func MoveAttrsOutOfGroup(group string, keys ...string) AttrReplacer {
+	keys = slices.Clone(keys)
+
+	return func(_ []string, attr slog.Attr) slog.Attr {
+		if attr.Key != group || attr.Value.Kind() != slog.KindGroup {
+			return attr
+		}
+
+		var kept, moved []slog.Attr
+
+		for _, member := range attr.Value.Group() {
+			if slices.Contains(keys, member.Key) {
+				moved = append(moved, member)
+			} else {
+				kept = append(kept, member)
+			}
+		}
+
+		if len(moved) == 0 {
+			return attr
+		}
+
+		members := make([]slog.Attr, 0, len(moved)+1)
+		members = append(members, slog.Attr{Key: group, Value: slog.GroupValue(kept...)})
+		members = append(members, moved...)
+
+		return slog.Attr{Value: slog.GroupValue(members...)}
+	}
+}
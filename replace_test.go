@@ -0,0 +1,103 @@
+package slogx_test
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/pamburus/go-tst/tst"
+	"github.com/pamburus/slogx"
+	"github.com/pamburus/slogx/internal/mock"
+)
+
+func TestAttrReplacer(tt *testing.T) {
+	t := New(tt)
+
+	someTime := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("Pipeline", func(t Test) {
+		cl := mock.NewCallLog()
+		handler := slogx.TweakHandler(mock.NewHandler(cl)).
+			WithAttrReplacer(slogx.RenameAttr("msg", "message")).
+			WithAttrReplacer(slogx.DropAttrs("debug")).
+			WithAttrReplacer(slogx.ReplaceAttrValue("name", func(v slog.Value) slog.Value {
+				return slog.StringValue(strings.ToUpper(v.String()))
+			})).
+			WithAttrReplacer(slogx.MoveAttrToGroup("trace", "otel")).
+			WithAttrReplacer(slogx.MoveAttrsOutOfGroup("http", "status")).
+			Result()
+
+		handler = handler.WithAttrs([]slog.Attr{
+			slog.String("msg", "m"),
+			slog.Bool("debug", true),
+		})
+		record := slog.NewRecord(someTime, slog.LevelInfo, "m1", 0)
+		record.AddAttrs(
+			slog.String("name", "x"),
+			slog.String("trace", "t1"),
+			slog.Group("http", slog.String("method", "GET"), slog.Int("status", 200)),
+		)
+
+		t.Expect(handler.Handle(context.Background(), record)).ToNot(HaveOccurred())
+		t.Expect(cl.Calls()...).To(Equal(
+			mock.HandlerWithAttrs{
+				Instance: "0",
+				Attrs:    []mock.Attr{{Key: "message", Value: "m"}},
+			},
+			mock.HandlerHandle{
+				Instance: "0.1",
+				Record: mock.Record{
+					Time:    someTime,
+					Message: "m1",
+					Level:   slog.LevelInfo,
+					Attrs: []mock.Attr{
+						{Key: "name", Value: "X"},
+						{Key: "otel", Value: []slog.Attr{slog.String("trace", "t1")}},
+						{Key: "http", Value: []slog.Attr{slog.String("method", "GET")}},
+						{Key: "status", Value: int64(200)},
+					},
+				},
+			},
+		))
+	})
+
+	t.Run("Groups", func(t Test) {
+		cl := mock.NewCallLog()
+
+		var seen [][]string
+
+		handler := slogx.TweakHandler(mock.NewHandler(cl)).
+			WithAttrReplacer(func(groups []string, attr slog.Attr) slog.Attr {
+				seen = append(seen, append(groups, attr.Key))
+
+				return attr
+			}).
+			Result()
+
+		handler = handler.WithGroup("g1")
+		handler = handler.WithAttrs([]slog.Attr{slog.String("a", "v")})
+		handler = handler.WithGroup("g2")
+		record := slog.NewRecord(someTime, slog.LevelInfo, "m1", 0)
+		record.AddAttrs(slog.Group("g3", slog.String("b", "v")))
+
+		t.Expect(handler.Handle(context.Background(), record)).ToNot(HaveOccurred())
+		t.Expect(seen).To(Equal([][]string{
+			{"g1", "a"},
+			{"g1", "g2", "g3", "b"},
+			{"g1", "g2", "g3"},
+		}))
+	})
+
+	t.Run("DropAll", func(t Test) {
+		cl := mock.NewCallLog()
+		base := mock.NewHandler(cl)
+		handler := slogx.TweakHandler(base).
+			WithAttrReplacer(slogx.DropAttrs("a")).
+			Result()
+
+		t.Expect(handler.WithAttrs([]slog.Attr{slog.String("a", "v")})).To(Equal(handler))
+		t.Expect(cl.Calls()).To(BeZero())
+	})
+}
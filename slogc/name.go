@@ -3,6 +3,7 @@ package slogc
 import (
 	"context"
 	"log/slog"
+	"strings"
 )
 
 // WithName returns a new context with the logger name composed
@@ -43,6 +44,33 @@ func NameAttr(key string) func(ctx context.Context) slog.Attr {
 	}
 }
 
+// NameLevel returns a function providing the minimum level for the logger name from the context
+// that can be used in [slogx.TweakHandlerBuilder.WithContextLevel].
+// The levels map contains minimum levels for logger names.
+// A level for a name also applies to all names nested into it using [WithName] unless they have their own level.
+// If no level is found for the logger name, nil is returned.
+func NameLevel(levels map[string]slog.Leveler) func(ctx context.Context) slog.Leveler {
+	return func(ctx context.Context) slog.Leveler {
+		name := Name(ctx)
+		if name == "" {
+			return nil
+		}
+
+		for {
+			if level, ok := levels[name]; ok {
+				return level
+			}
+
+			i := strings.LastIndexByte(name, '.')
+			if i < 0 {
+				return nil
+			}
+
+			name = name[:i]
+		}
+	}
+}
+
 // ---
 
 var contextKeyName int
@@ -216,6 +216,33 @@ func TestName(tt *testing.T) {
 	})
 }
 
+func TestNameLevel(tt *testing.T) {
+	t := New(tt)
+
+	ctx := context.Background()
+	level := slogc.NameLevel(map[string]slog.Leveler{
+		"a":     slog.LevelWarn,
+		"a.b.c": slog.LevelDebug,
+	})
+
+	t.Expect(level(ctx)).To(BeNil())
+	t.Expect(level(slogc.WithName(ctx, "x"))).To(BeNil())
+	t.Expect(level(slogc.WithName(ctx, "a"))).To(Equal(slog.LevelWarn))
+	t.Expect(level(slogc.WithName(slogc.WithName(ctx, "a"), "b"))).To(Equal(slog.LevelWarn))
+	t.Expect(level(slogc.WithName(ctx, "a.b.c"))).To(Equal(slog.LevelDebug))
+	t.Expect(level(slogc.WithName(ctx, "a.b.c.d"))).To(Equal(slog.LevelDebug))
+	t.Expect(level(slogc.WithName(ctx, "ab"))).To(BeNil())
+
+	handler := slogx.TweakHandler(slogx.Discard()).
+		WithLevel(slog.LevelInfo).
+		WithContextLevel(level).
+		Result()
+
+	t.Expect(handler.Enabled(ctx, slog.LevelInfo)).To(BeTrue())
+	t.Expect(handler.Enabled(slogc.WithName(ctx, "a"), slog.LevelInfo)).To(BeFalse())
+	t.Expect(handler.Enabled(slogc.WithName(ctx, "a.b.c"), slog.LevelDebug)).To(BeTrue())
+}
+
 func newHandlerWithName(handler slog.Handler, attrKey string) slog.Handler {
 	if attrKey == "" {
 		return handler
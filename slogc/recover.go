@@ -0,0 +1,29 @@
+package slogc
+
+import (
+	"context"
+
+	"github.com/pamburus/slogx"
+)
+
+// Recover recovers from a panic, if any, and logs it using the [Logger] from the context.
+// It must be called directly by a deferred function, for example:
+//
+//	defer slogc.Recover(ctx, nil)
+//
+// See [slogx.RecoverOptions] for the available options, nil options mean default options.
+func Recover(ctx context.Context, options *slogx.RecoverOptions) {
+	if value := recover(); value != nil {
+		Get(ctx).HandlePanic(ctx, value, options)
+	}
+}
+
+// Go runs fn in a new goroutine and logs a panic in it, if any, using the [Logger] from the context
+// instead of crashing the program.
+func Go(ctx context.Context, fn func(context.Context)) {
+	go func() {
+		defer Recover(ctx, nil)
+
+		fn(ctx)
+	}()
+}
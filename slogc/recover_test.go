@@ -0,0 +1,62 @@
+package slogc_test
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	. "github.com/pamburus/go-tst/tst"
+	"github.com/pamburus/slogx"
+	"github.com/pamburus/slogx/internal/mock"
+	"github.com/pamburus/slogx/slogc"
+)
+
+func TestRecover(tt *testing.T) {
+	t := New(tt)
+
+	setup := func() (context.Context, *mock.CallLog) {
+		cl := mock.NewCallLog()
+		logger := slogx.NewContextLogger(mock.NewHandler(cl)).WithSource(false)
+
+		return slogc.New(context.Background(), logger), cl
+	}
+
+	t.Run("Recover", func(t Test) {
+		ctx, cl := setup()
+		ctx = slogc.With(ctx, slog.String("a", "v"))
+
+		func() {
+			defer slogc.Recover(ctx, &slogx.RecoverOptions{Level: slog.LevelWarn})
+
+			panic("p1")
+		}()
+
+		calls := cl.Calls().WithoutTime()
+		t.Expect(calls).To(HaveLen(3))
+
+		record := calls[2].(mock.HandlerHandle).Record
+		t.Expect(record.Level).To(Equal(slog.LevelWarn))
+		t.Expect(record.Attrs[0]).To(Equal(mock.Attr{Key: slogx.PanicKey, Value: "p1"}))
+	})
+
+	t.Run("Go", func(t Test) {
+		ctx, cl := setup()
+
+		slogc.Go(ctx, func(context.Context) {
+			panic("p2")
+		})
+
+		deadline := time.Now().Add(5 * time.Second)
+		for len(cl.Calls()) < 2 && time.Now().Before(deadline) {
+			time.Sleep(time.Millisecond)
+		}
+
+		calls := cl.Calls().WithoutTime()
+		t.Expect(calls).To(HaveLen(2))
+
+		record := calls[1].(mock.HandlerHandle).Record
+		t.Expect(record.Level).To(Equal(slog.LevelError))
+		t.Expect(record.Attrs[0]).To(Equal(mock.Attr{Key: slogx.PanicKey, Value: "p2"}))
+	})
+}
@@ -6,6 +6,7 @@ import (
 	"log/slog"
 
 	"github.com/pamburus/slogx"
+	"github.com/pamburus/slogx/internal/ctxkey"
 )
 
 // Logger is an alias for [slogx.ContextLogger].
@@ -17,14 +18,14 @@ func New(ctx context.Context, logger *Logger) context.Context {
 		ctx = context.Background()
 	}
 
-	return context.WithValue(ctx, &contextKeyLogger, logger)
+	return context.WithValue(ctx, &ctxkey.Logger, logger)
 }
 
 // Get returns a [Logger] from the given context stored by [New].
 // If the context is nil or the logger is not found, a [Default] logger is returned.
 func Get(ctx context.Context) *Logger {
 	if ctx != nil {
-		if logger, ok := ctx.Value(&contextKeyLogger).(*Logger); ok {
+		if logger, ok := ctx.Value(&ctxkey.Logger).(*Logger); ok {
 			return logger
 		}
 	}
@@ -52,8 +53,19 @@ func WithSource(ctx context.Context, enabled bool) context.Context {
 	return New(ctx, Get(ctx).WithSource(enabled))
 }
 
+// WithSourceLevel returns a new context with a modified logger including the source information
+// only for records at the given level or above, see [slogx.ContextLogger.WithSourceLevel].
+func WithSourceLevel(ctx context.Context, level slog.Leveler) context.Context {
+	return New(ctx, Get(ctx).WithSourceLevel(level))
+}
+
 // ---
 
+// Trace logs a message at trace level.
+func Trace(ctx context.Context, msg string, attrs ...slog.Attr) {
+	Get(ctx).LogWithCallerSkip(ctx, 1, slogx.LevelTrace, msg, attrs...)
+}
+
 // Debug logs a message at debug level.
 func Debug(ctx context.Context, msg string, attrs ...slog.Attr) {
 	Get(ctx).LogWithCallerSkip(ctx, 1, slog.LevelDebug, msg, attrs...)
@@ -74,11 +86,16 @@ func Error(ctx context.Context, msg string, attrs ...slog.Attr) {
 	Get(ctx).LogWithCallerSkip(ctx, 1, slog.LevelError, msg, attrs...)
 }
 
+// Fatal logs a message at fatal level, flushes the logger's handler
+// and terminates the program using [slogx.Exit] with status code 1.
+func Fatal(ctx context.Context, msg string, attrs ...slog.Attr) {
+	logger := Get(ctx)
+	logger.LogWithCallerSkip(ctx, 1, slogx.LevelFatal, msg, attrs...)
+	_ = logger.Flush(ctx)
+	slogx.Exit(1)
+}
+
 // Log logs a message with attributes at the given level.
 func Log(ctx context.Context, level slog.Level, msg string, attrs ...slog.Attr) {
 	Get(ctx).LogWithCallerSkip(ctx, 1, level, msg, attrs...)
 }
-
-// ---
-
-var contextKeyLogger int
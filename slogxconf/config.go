@@ -0,0 +1,100 @@
+// Package slogxconf builds [slogx.Logger] and its handler tree from a declarative configuration.
+package slogxconf
+
+// Config is a declarative logging configuration.
+// It can be decoded from JSON or YAML.
+type Config struct {
+	// Level is the minimum level of log records, for example "debug" or "warn+1", see [slogx.ParseLevel].
+	// The default is "info".
+	Level string `json:"level,omitempty" yaml:"level,omitempty"`
+	// Format is the default format of the outputs, one of [FormatJSON], [FormatText], [FormatLogfmt] and [FormatConsole].
+	// The default is [FormatJSON].
+	Format string `json:"format,omitempty" yaml:"format,omitempty"`
+	// Source specifies whether to include the source location into log records.
+	Source bool `json:"source,omitempty" yaml:"source,omitempty"`
+	// Outputs is the list of outputs, a single [OutputStderr] output is used if it is empty.
+	Outputs []Output `json:"outputs,omitempty" yaml:"outputs,omitempty"`
+	// Names contains minimum levels for logger names set using [slogc.WithName],
+	// see [slogc.NameLevel] for details.
+	Names map[string]string `json:"names,omitempty" yaml:"names,omitempty"`
+	// NameKey is the key of the attribute containing the logger name, see [slogc.NameAttr].
+	// The logger name is not added if it is empty.
+	NameKey string `json:"nameKey,omitempty" yaml:"nameKey,omitempty"`
+	// Redaction contains the redaction rules.
+	Redaction *Redaction `json:"redaction,omitempty" yaml:"redaction,omitempty"`
+	// Attrs contains static attributes added to all log records, in sorted key order.
+	Attrs map[string]any `json:"attrs,omitempty" yaml:"attrs,omitempty"`
+}
+
+// Output is a log output configuration.
+type Output struct {
+	// Type is the type of the output, one of [OutputStdout], [OutputStderr], [OutputFile] and [OutputNetwork].
+	Type string `json:"type" yaml:"type"`
+	// Format is the format of the output, the default format of the [Config] is used if it is empty.
+	Format string `json:"format,omitempty" yaml:"format,omitempty"`
+	// Level is the minimum level of log records written to the output.
+	// It can only further restrict the levels enabled by the [Config].
+	Level string `json:"level,omitempty" yaml:"level,omitempty"`
+	// Path is the path of the file for [OutputFile] outputs.
+	Path string `json:"path,omitempty" yaml:"path,omitempty"`
+	// MaxSize is the maximum size of the file in bytes before it is rotated for [OutputFile] outputs.
+	// The file is not rotated if it is zero.
+	MaxSize int64 `json:"maxSize,omitempty" yaml:"maxSize,omitempty"`
+	// MaxBackups is the maximum number of rotated files to keep for [OutputFile] outputs.
+	// Rotated files are named by appending ".1", ".2" and so on to the path, ".1" being the most recent one.
+	MaxBackups int `json:"maxBackups,omitempty" yaml:"maxBackups,omitempty"`
+	// Network is the network for [OutputNetwork] outputs, for example "tcp", "udp" or "unix".
+	Network string `json:"network,omitempty" yaml:"network,omitempty"`
+	// Address is the address for [OutputNetwork] outputs.
+	// The connection is re-established when it breaks, and records are kept in memory while it is down,
+	// see [slogxnet.NewWriter].
+	Address string `json:"address,omitempty" yaml:"address,omitempty"`
+}
+
+// Redaction is a redaction rules configuration, see [slogx.TweakHandlerBuilder.WithRedaction].
+type Redaction struct {
+	// Keys is a list of attribute keys to redact in any group.
+	Keys []string `json:"keys,omitempty" yaml:"keys,omitempty"`
+	// Paths is a list of dot-separated attribute paths to redact, for example "user.password".
+	Paths []string `json:"paths,omitempty" yaml:"paths,omitempty"`
+	// Patterns is a list of regular expressions matching sensitive parts of string values.
+	// Names "email", "card" and "bearer" refer to [slogx.EmailPattern], [slogx.CardNumberPattern]
+	// and [slogx.BearerTokenPattern] respectively.
+	Patterns []string `json:"patterns,omitempty" yaml:"patterns,omitempty"`
+	// Hash specifies whether to replace sensitive values with their salted hashes instead of a mask,
+	// see [slogx.HashRedactor].
+	Hash bool `json:"hash,omitempty" yaml:"hash,omitempty"`
+	// Salt is the salt used for hashing.
+	Salt string `json:"salt,omitempty" yaml:"salt,omitempty"`
+	// Mask is the mask used instead of sensitive values if Hash is false, the default is "***".
+	Mask string `json:"mask,omitempty" yaml:"mask,omitempty"`
+}
+
+// ---
+
+// Supported formats.
+const (
+	// FormatJSON is the format of [slog.JSONHandler].
+	FormatJSON = "json"
+	// FormatText is the format of [slog.TextHandler].
+	FormatText = "text"
+	// FormatLogfmt is an alias for [FormatText] because it produces logfmt compatible output.
+	FormatLogfmt = "logfmt"
+	// FormatConsole is a human-readable format intended for terminals,
+	// having time, level, source location and message at the beginning of the line followed by attributes in text format.
+	FormatConsole = "console"
+)
+
+// Supported output types.
+const (
+	OutputStdout  = "stdout"
+	OutputStderr  = "stderr"
+	OutputFile    = "file"
+	OutputNetwork = "network"
+)
+
+// Environment variables overriding the configuration, see [New].
+const (
+	EnvLevel  = "LOG_LEVEL"
+	EnvFormat = "LOG_FORMAT"
+)
@@ -0,0 +1,122 @@
+package slogxconf
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/pamburus/slogx"
+)
+
+func newConsoleHandler(writer io.Writer, options *slog.HandlerOptions) slog.Handler {
+	s := &consoleState{writer: writer}
+
+	return &consoleHandler{
+		state:  s,
+		source: options.AddSource,
+		base: slog.NewTextHandler(&s.buf, &slog.HandlerOptions{
+			Level:       options.Level,
+			ReplaceAttr: replaceConsoleAttr,
+		}),
+	}
+}
+
+// ---
+
+// consoleHandler renders records in a human-readable form, for example:
+//
+//	2024-01-02 03:04:05.678 INFO  file.go:12 > message key=value
+//
+// The attributes are rendered by the text handler, the header is rendered by the console handler itself.
+type consoleHandler struct {
+	state  *consoleState
+	source bool
+	base   slog.Handler
+}
+
+type consoleState struct {
+	mu     sync.Mutex
+	writer io.Writer
+	buf    bytes.Buffer
+}
+
+func (h *consoleHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.base.Enabled(ctx, level)
+}
+
+func (h *consoleHandler) Handle(ctx context.Context, record slog.Record) error {
+	s := h.state
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.buf.Reset()
+
+	header := make([]byte, 0, 64)
+	if !record.Time.IsZero() {
+		header = record.Time.AppendFormat(header, time.DateTime+".000")
+		header = append(header, ' ')
+	}
+
+	level := slogx.LevelName(record.Level)
+	header = append(header, level...)
+
+	for i := len(level); i < 5; i++ {
+		header = append(header, ' ')
+	}
+
+	if h.source && record.PC != 0 {
+		frame, _ := runtime.CallersFrames([]uintptr{record.PC}).Next()
+		header = append(header, ' ')
+		header = append(header, filepath.Base(frame.File)...)
+		header = append(header, ':')
+		header = strconv.AppendInt(header, int64(frame.Line), 10)
+	}
+
+	header = append(header, " > "...)
+	header = append(header, record.Message...)
+
+	err := h.base.Handle(ctx, record)
+	if err != nil {
+		return err
+	}
+
+	attrs := bytes.TrimSuffix(s.buf.Bytes(), []byte("\n"))
+	if len(attrs) != 0 {
+		header = append(header, ' ')
+		header = append(header, attrs...)
+	}
+
+	header = append(header, '\n')
+
+	_, err = s.writer.Write(header)
+
+	return err
+}
+
+func (h *consoleHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &consoleHandler{h.state, h.source, h.base.WithAttrs(attrs)}
+}
+
+func (h *consoleHandler) WithGroup(key string) slog.Handler {
+	return &consoleHandler{h.state, h.source, h.base.WithGroup(key)}
+}
+
+// ---
+
+func replaceConsoleAttr(groups []string, attr slog.Attr) slog.Attr {
+	if len(groups) == 0 {
+		switch attr.Key {
+		case slog.TimeKey, slog.LevelKey, slog.MessageKey:
+			return slog.Attr{}
+		}
+	}
+
+	return attr
+}
@@ -0,0 +1,145 @@
+package slogxconf
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+)
+
+func openRotatingFile(path string, maxSize int64, maxBackups int) (*rotatingFile, error) {
+	f := &rotatingFile{
+		path:       path,
+		maxSize:    maxSize,
+		maxBackups: maxBackups,
+	}
+
+	err := f.open()
+	if err != nil {
+		return nil, err
+	}
+
+	return f, nil
+}
+
+// ---
+
+// rotatingFile is a file that is rotated when its size exceeds the limit.
+type rotatingFile struct {
+	path       string
+	maxSize    int64
+	maxBackups int
+
+	mu     sync.Mutex
+	file   *os.File
+	size   int64
+	closed bool
+}
+
+// Write writes the data to the file rotating it first if the data does not fit.
+// If the rotation fails, the data is written to the current file and the rotation error is returned,
+// so nothing is lost, and the rotation is retried on the next write.
+func (f *rotatingFile) Write(p []byte) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.closed {
+		return 0, os.ErrClosed
+	}
+
+	var rotateErr error
+
+	switch {
+	case f.file == nil:
+		rotateErr = f.open()
+	case f.maxSize > 0 && f.size > 0 && f.size+int64(len(p)) > f.maxSize:
+		rotateErr = f.rotate()
+	}
+
+	if f.file == nil {
+		return 0, rotateErr
+	}
+
+	n, err := f.file.Write(p)
+	f.size += int64(n)
+
+	return n, errors.Join(rotateErr, err)
+}
+
+func (f *rotatingFile) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.closed {
+		return nil
+	}
+
+	f.closed = true
+
+	if f.file == nil {
+		return nil
+	}
+
+	err := f.file.Close()
+	f.file = nil
+
+	return err
+}
+
+func (f *rotatingFile) open() error {
+	file, err := os.OpenFile(f.path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o644)
+	if err != nil {
+		return err
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		return errors.Join(err, file.Close())
+	}
+
+	f.file = file
+	f.size = info.Size()
+
+	return nil
+}
+
+// rotate closes the file, shifts the backups and opens a new file.
+// The file is reopened even if the shifting fails, so the writes can continue.
+// If reopening fails as well, it is retried on the next write.
+func (f *rotatingFile) rotate() error {
+	err := f.file.Close()
+	f.file = nil
+
+	if err == nil {
+		err = f.shift()
+	}
+
+	return errors.Join(err, f.open())
+}
+
+func (f *rotatingFile) shift() error {
+	var err error
+
+	if f.maxBackups > 0 {
+		for i := f.maxBackups - 1; i > 0; i-- {
+			err = os.Rename(f.backup(i), f.backup(i+1))
+			if err != nil && !os.IsNotExist(err) {
+				return err
+			}
+		}
+
+		err = os.Rename(f.path, f.backup(1))
+	} else {
+		err = os.Remove(f.path)
+	}
+
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	return nil
+}
+
+func (f *rotatingFile) backup(i int) string {
+	return fmt.Sprintf("%s.%d", f.path, i)
+}
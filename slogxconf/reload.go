@@ -0,0 +1,275 @@
+package slogxconf
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"os"
+	"os/signal"
+	"sync"
+	"time"
+
+	"github.com/pamburus/slogx"
+)
+
+// NewReloader builds a new [Reloader] with the initial configuration.
+func NewReloader(config Config) (*Reloader, error) {
+	handler, closer, err := NewHandler(config)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &Reloader{}
+	r.tree = &reloadTree{closer: closer}
+	r.handler = slogx.NewSwapHandler(&reloadHandler{reloader: r, tree: r.tree, base: handler})
+	r.source.Set(sourceLevel(config.Source))
+
+	// Source locations are captured depending on the level variable updated on each reload,
+	// so they are captured only while the current configuration enables them.
+	r.logger = slogx.New(r.handler).WithSourceLevel(&r.source)
+
+	return r, nil
+}
+
+// LoadFile loads the configuration from the JSON file at the given path.
+func LoadFile(path string) (Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Config{}, err
+	}
+
+	var config Config
+
+	err = json.Unmarshal(data, &config)
+	if err != nil {
+		return Config{}, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	return config, nil
+}
+
+// ---
+
+// Reloader holds a handler tree built from a [Config] that can be rebuilt from a new configuration at runtime.
+// Loggers derived from [Reloader.Logger] and [Reloader.Handler], including the ones with attributes and groups,
+// keep working after a reload and use the new handler tree, see [slogx.SwapHandler].
+type Reloader struct {
+	mu      sync.Mutex
+	handler *slogx.SwapHandler
+	tree    *reloadTree
+	source  slog.LevelVar
+	logger  *slogx.Logger
+}
+
+// Logger returns the logger using the reloadable handler.
+func (r *Reloader) Logger() *slogx.Logger {
+	return r.logger
+}
+
+// Handler returns the reloadable handler.
+func (r *Reloader) Handler() *slogx.SwapHandler {
+	return r.handler
+}
+
+// Reload builds a new handler tree according to the configuration, swaps it in
+// and closes the previous one, see [NewHandler].
+// The previous handler tree is closed after the records being handled by it are handled,
+// and records reaching it after that are handled by the new handler tree.
+// If the new configuration is invalid, the previous handler tree is kept and an error is returned.
+func (r *Reloader) Reload(config Config) error {
+	handler, closer, err := NewHandler(config)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	previous := r.tree
+	r.tree = &reloadTree{closer: closer}
+	r.handler.Swap(&reloadHandler{reloader: r, tree: r.tree, base: handler})
+	r.source.Set(sourceLevel(config.Source))
+
+	return previous.close(true)
+}
+
+// Close closes the current handler tree.
+// It waits for the records being handled to be handled first.
+func (r *Reloader) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.tree.close(false)
+}
+
+// WatchSignals reloads the configuration returned by load each time any of the signals is received,
+// for example [syscall.SIGHUP], until the context is canceled.
+// Reload failures are logged using [Reloader.Logger].
+func (r *Reloader) WatchSignals(ctx context.Context, load func() (Config, error), signals ...os.Signal) {
+	ch := make(chan os.Signal, 1)
+	signal.Notify(ch, signals...)
+	defer signal.Stop(ch)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ch:
+			r.reload(ctx, load)
+		}
+	}
+}
+
+// WatchFile polls the JSON configuration file at the given path with the given interval
+// and reloads the configuration each time the modification time or the size of the file changes,
+// until the context is canceled, see [LoadFile].
+// Reload failures are logged using [Reloader.Logger].
+func (r *Reloader) WatchFile(ctx context.Context, path string, interval time.Duration) {
+	last, _ := os.Stat(path)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			info, err := os.Stat(path)
+			if err != nil {
+				if !errors.Is(err, os.ErrNotExist) || last != nil {
+					r.logger.ErrorContext(ctx, "failed to check logging configuration", slogx.ErrorAttr(err))
+				}
+
+				last = nil
+
+				continue
+			}
+
+			if last != nil && info.ModTime().Equal(last.ModTime()) && info.Size() == last.Size() {
+				continue
+			}
+
+			last = info
+
+			r.reload(ctx, func() (Config, error) {
+				return LoadFile(path)
+			})
+		}
+	}
+}
+
+func (r *Reloader) reload(ctx context.Context, load func() (Config, error)) {
+	config, err := load()
+	if err == nil {
+		err = r.Reload(config)
+	}
+
+	if err != nil {
+		r.logger.ErrorContext(ctx, "failed to reload logging configuration", slogx.ErrorAttr(err))
+	}
+}
+
+// ---
+
+// reloadTree tracks the calls of a handler tree in progress, so the tree can be closed once they complete.
+type reloadTree struct {
+	mu      sync.RWMutex
+	closer  func() error
+	closed  bool
+	retired bool
+}
+
+// close waits for the calls in progress and closes the tree.
+// Calls made after that to a retired tree are redirected to the current tree.
+func (t *reloadTree) close(retired bool) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.closed {
+		return nil
+	}
+
+	t.closed = true
+	t.retired = retired
+
+	return t.closer()
+}
+
+// ---
+
+// reloadHandler is a handler of a tree built by a [Reloader].
+// It keeps the chain of calls used to derive it, so the equivalent handler can be derived
+// from the current tree when a record reaches a tree that is already closed.
+type reloadHandler struct {
+	reloader *Reloader
+	tree     *reloadTree
+	base     slog.Handler
+	parent   *reloadHandler
+	attrs    []slog.Attr
+	group    string
+}
+
+func (h *reloadHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.base.Enabled(ctx, level)
+}
+
+func (h *reloadHandler) Handle(ctx context.Context, record slog.Record) error {
+	h.tree.mu.RLock()
+
+	if h.tree.retired {
+		h.tree.mu.RUnlock()
+
+		return h.current().Handle(ctx, record)
+	}
+
+	defer h.tree.mu.RUnlock()
+
+	return h.base.Handle(ctx, record)
+}
+
+func (h *reloadHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+
+	return &reloadHandler{h.reloader, h.tree, h.base.WithAttrs(attrs), h, attrs, ""}
+}
+
+func (h *reloadHandler) WithGroup(key string) slog.Handler {
+	if key == "" {
+		return h
+	}
+
+	return &reloadHandler{h.reloader, h.tree, h.base.WithGroup(key), h, nil, key}
+}
+
+func (h *reloadHandler) Flush(ctx context.Context) error {
+	return slogx.Flush(ctx, h.base)
+}
+
+// current returns the handler equivalent to this one derived from the current handler tree.
+func (h *reloadHandler) current() slog.Handler {
+	if h.parent == nil {
+		return h.reloader.handler
+	}
+
+	if h.group != "" {
+		return h.parent.current().WithGroup(h.group)
+	}
+
+	return h.parent.current().WithAttrs(h.attrs)
+}
+
+// ---
+
+func sourceLevel(enabled bool) slog.Level {
+	if enabled {
+		return slog.Level(math.MinInt)
+	}
+
+	return slog.Level(math.MaxInt)
+}
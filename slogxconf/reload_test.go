@@ -0,0 +1,229 @@
+package slogxconf_test
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+
+	. "github.com/pamburus/go-tst/tst"
+	"github.com/pamburus/slogx/slogxconf"
+)
+
+func TestReloader(tt *testing.T) {
+	t := New(tt)
+
+	fileConfig := func(path string) slogxconf.Config {
+		return slogxconf.Config{
+			Format:  slogxconf.FormatText,
+			Outputs: []slogxconf.Output{{Type: slogxconf.OutputFile, Path: path}},
+		}
+	}
+
+	readLines := func(t Test, path string) []string {
+		data, err := os.ReadFile(path)
+		t.Expect(err).ToNot(HaveOccurred())
+
+		var lines []string
+		for _, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+			_, line, _ = strings.Cut(line, " ")
+			lines = append(lines, line)
+		}
+
+		return lines
+	}
+
+	// waitFor repeats the action until the file at the given path is created.
+	waitFor := func(t Test, path string, action func()) {
+		for i := 0; i < 500; i++ {
+			action()
+
+			if _, err := os.Stat(path); err == nil {
+				return
+			}
+
+			time.Sleep(10 * time.Millisecond)
+		}
+
+		t.Fatalf("%s was not created", path)
+	}
+
+	t.Run("Reload", func(t Test) {
+		dir := tt.TempDir()
+
+		reloader, err := slogxconf.NewReloader(fileConfig(filepath.Join(dir, "1.log")))
+		t.Expect(err).ToNot(HaveOccurred())
+
+		logger := reloader.Logger().WithLongTerm(slog.Int("a", 1)).WithGroup("g")
+		logger.Info("m1", slog.Int("b", 2))
+
+		t.Expect(reloader.Reload(slogxconf.Config{Format: "xml"})).To(HaveOccurred())
+		logger.Info("m2")
+
+		t.Expect(reloader.Reload(fileConfig(filepath.Join(dir, "2.log")))).ToNot(HaveOccurred())
+		logger.Info("m3")
+		t.Expect(reloader.Close()).ToNot(HaveOccurred())
+
+		t.Expect(readLines(t, filepath.Join(dir, "1.log"))).To(Equal([]string{
+			"level=INFO msg=m1 a=1 g.b=2",
+			"level=INFO msg=m2 a=1",
+		}))
+		t.Expect(readLines(t, filepath.Join(dir, "2.log"))).To(Equal([]string{
+			"level=INFO msg=m3 a=1",
+		}))
+	})
+
+	t.Run("InFlight", func(t Test) {
+		dir := tt.TempDir()
+
+		reloader, err := slogxconf.NewReloader(fileConfig(filepath.Join(dir, "0.log")))
+		t.Expect(err).ToNot(HaveOccurred())
+
+		stale := reloader.Handler().Handler().WithAttrs([]slog.Attr{slog.Int("a", 1)})
+
+		const workers, records, reloads = 4, 100, 10
+
+		errs := make(chan error, workers*records)
+
+		var wg sync.WaitGroup
+
+		for range workers {
+			wg.Add(1)
+
+			go func() {
+				defer wg.Done()
+
+				for range records {
+					errs <- stale.Handle(context.Background(), slog.NewRecord(time.Now(), slog.LevelInfo, "m1", 0))
+				}
+			}()
+		}
+
+		for i := range reloads {
+			t.Expect(reloader.Reload(fileConfig(filepath.Join(dir, strconv.Itoa(i+1)+".log")))).ToNot(HaveOccurred())
+		}
+
+		wg.Wait()
+		close(errs)
+		t.Expect(reloader.Close()).ToNot(HaveOccurred())
+
+		for err := range errs {
+			t.Expect(err).ToNot(HaveOccurred())
+		}
+
+		var lines []string
+		for i := range reloads + 1 {
+			path := filepath.Join(dir, strconv.Itoa(i)+".log")
+			if info, err := os.Stat(path); err == nil && info.Size() != 0 {
+				lines = append(lines, readLines(t, path)...)
+			}
+		}
+
+		t.Expect(lines).To(HaveLen(workers * records))
+		t.Expect(lines[0]).To(Equal("level=INFO msg=m1 a=1"))
+	})
+
+	t.Run("Source", func(t Test) {
+		dir := tt.TempDir()
+
+		reloader, err := slogxconf.NewReloader(fileConfig(filepath.Join(dir, "1.log")))
+		t.Expect(err).ToNot(HaveOccurred())
+
+		reloader.Logger().Info("m1")
+
+		config := fileConfig(filepath.Join(dir, "2.log"))
+		config.Source = true
+		t.Expect(reloader.Reload(config)).ToNot(HaveOccurred())
+
+		reloader.Logger().Info("m2")
+		t.Expect(reloader.Close()).ToNot(HaveOccurred())
+
+		t.Expect(readLines(t, filepath.Join(dir, "1.log"))).To(Equal([]string{"level=INFO msg=m1"}))
+
+		lines := readLines(t, filepath.Join(dir, "2.log"))
+		t.Expect(lines).To(HaveLen(1))
+		t.Expect(strings.HasPrefix(lines[0], "level=INFO source=")).To(BeTrue())
+	})
+
+	t.Run("WatchFile", func(t Test) {
+		dir := tt.TempDir()
+		configPath := filepath.Join(dir, "config.json")
+
+		writeConfig := func(config slogxconf.Config) {
+			data, err := json.Marshal(config)
+			t.Expect(err).ToNot(HaveOccurred())
+			t.Expect(os.WriteFile(configPath+".tmp", data, 0o600)).ToNot(HaveOccurred())
+			t.Expect(os.Rename(configPath+".tmp", configPath)).ToNot(HaveOccurred())
+		}
+
+		writeConfig(fileConfig(filepath.Join(dir, "1.log")))
+
+		config, err := slogxconf.LoadFile(configPath)
+		t.Expect(err).ToNot(HaveOccurred())
+
+		reloader, err := slogxconf.NewReloader(config)
+		t.Expect(err).ToNot(HaveOccurred())
+
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan struct{})
+
+		go func() {
+			defer close(done)
+			reloader.WatchFile(ctx, configPath, 5*time.Millisecond)
+		}()
+
+		reloader.Logger().Info("m1")
+		waitFor(t, filepath.Join(dir, "22.log"), func() {
+			writeConfig(fileConfig(filepath.Join(dir, "22.log")))
+		})
+		reloader.Logger().Info("m2")
+
+		cancel()
+		<-done
+		t.Expect(reloader.Close()).ToNot(HaveOccurred())
+
+		t.Expect(readLines(t, filepath.Join(dir, "1.log"))).To(Equal([]string{"level=INFO msg=m1"}))
+		t.Expect(readLines(t, filepath.Join(dir, "22.log"))).To(Equal([]string{"level=INFO msg=m2"}))
+	})
+
+	t.Run("WatchSignals", func(t Test) {
+		dir := tt.TempDir()
+
+		reloader, err := slogxconf.NewReloader(fileConfig(filepath.Join(dir, "1.log")))
+		t.Expect(err).ToNot(HaveOccurred())
+
+		// Keep signals sent before the watcher subscribes from terminating the test.
+		guard := make(chan os.Signal, 1)
+		signal.Notify(guard, syscall.SIGHUP)
+		defer signal.Stop(guard)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan struct{})
+
+		go func() {
+			defer close(done)
+			reloader.WatchSignals(ctx, func() (slogxconf.Config, error) {
+				return fileConfig(filepath.Join(dir, "2.log")), nil
+			}, syscall.SIGHUP)
+		}()
+
+		waitFor(t, filepath.Join(dir, "2.log"), func() {
+			t.Expect(syscall.Kill(os.Getpid(), syscall.SIGHUP)).ToNot(HaveOccurred())
+		})
+
+		cancel()
+		<-done
+
+		reloader.Logger().Info("m1")
+		t.Expect(reloader.Close()).ToNot(HaveOccurred())
+		t.Expect(readLines(t, filepath.Join(dir, "2.log"))).To(Equal([]string{"level=INFO msg=m1"}))
+	})
+}
@@ -0,0 +1,284 @@
+package slogxconf
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"math"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/pamburus/slogx"
+	"github.com/pamburus/slogx/slogc"
+	"github.com/pamburus/slogx/slogxnet"
+)
+
+// New builds a new [slogx.Logger] according to the configuration.
+// The [EnvLevel] and [EnvFormat] environment variables, if set, override
+// the level and the formats of all outputs respectively.
+// The returned close function flushes and closes the handlers and releases the resources held by the outputs,
+// such as files and network connections.
+func New(config Config) (*slogx.Logger, func() error, error) {
+	handler, closer, err := NewHandler(config)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return slogx.New(handler).WithSource(config.Source), closer, nil
+}
+
+// NewHandler builds a new [slog.Handler] tree according to the configuration.
+// See [New] for details.
+func NewHandler(config Config) (slog.Handler, func() error, error) {
+	config = applyEnv(config)
+
+	b := builder{config: config}
+
+	handler, err := b.build()
+	if err != nil {
+		return nil, nil, errors.Join(err, b.close())
+	}
+
+	return handler, func() error {
+		return errors.Join(slogx.Close(context.Background(), handler), b.close())
+	}, nil
+}
+
+// ---
+
+type builder struct {
+	config  Config
+	closers []io.Closer
+}
+
+func (b *builder) build() (slog.Handler, error) {
+	level, err := parseLevel(b.config.Level, slog.LevelInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	outputs := b.config.Outputs
+	if len(outputs) == 0 {
+		outputs = []Output{{Type: OutputStderr}}
+	}
+
+	handlers := make([]slog.Handler, 0, len(outputs))
+	floor := slog.Level(math.MinInt)
+
+	for i, output := range outputs {
+		outputLevel, err := parseLevel(output.Level, slog.Level(math.MinInt))
+		if err != nil {
+			return nil, fmt.Errorf("slogxconf: output %d: %w", i, err)
+		}
+
+		handler, err := b.output(output, outputLevel)
+		if err != nil {
+			return nil, fmt.Errorf("slogxconf: output %d: %w", i, err)
+		}
+
+		handlers = append(handlers, handler)
+		floor = outputLevel
+	}
+
+	// Multiple outputs are joined, and the joined handler checks the level of each output on its own.
+	// A single output is not, and the tweaked handler does not consult its level,
+	// so the output level has to be taken into account by all the levels of the tweaked handler.
+	if len(handlers) > 1 {
+		floor = slog.Level(math.MinInt)
+	}
+
+	tweaks := slogx.TweakHandler(slogx.Join(handlers...)).WithLevel(max(level, floor))
+
+	if len(b.config.Names) != 0 {
+		levels := make(map[string]slog.Leveler, len(b.config.Names))
+
+		for name, value := range b.config.Names {
+			level, err := parseLevel(value, slog.LevelInfo)
+			if err != nil {
+				return nil, fmt.Errorf("slogxconf: name %q: %w", name, err)
+			}
+
+			levels[name] = max(level, floor)
+		}
+
+		tweaks = tweaks.WithContextLevel(slogc.NameLevel(levels))
+	}
+
+	if b.config.NameKey != "" {
+		tweaks = tweaks.WithDynamicAttr(slogc.NameAttr(b.config.NameKey))
+	}
+
+	if b.config.Redaction != nil {
+		rules, err := redactionRules(b.config.Redaction)
+		if err != nil {
+			return nil, err
+		}
+
+		tweaks = tweaks.WithRedaction(rules...)
+	}
+
+	if len(b.config.Attrs) != 0 {
+		tweaks = tweaks.WithStaticAttrs(staticAttrs(b.config.Attrs)...)
+	}
+
+	return tweaks.Result(), nil
+}
+
+func (b *builder) output(output Output, level slog.Level) (slog.Handler, error) {
+	writer, err := b.writer(output)
+	if err != nil {
+		return nil, err
+	}
+
+	options := &slog.HandlerOptions{
+		AddSource:   b.config.Source,
+		Level:       level,
+		ReplaceAttr: slogx.ReplaceLevelAttr,
+	}
+
+	switch format := cmp.Or(output.Format, b.config.Format, FormatJSON); format {
+	case FormatJSON:
+		return slog.NewJSONHandler(writer, options), nil
+	case FormatText, FormatLogfmt:
+		return slog.NewTextHandler(writer, options), nil
+	case FormatConsole:
+		return newConsoleHandler(writer, options), nil
+	default:
+		return nil, fmt.Errorf("unknown format %q", format)
+	}
+}
+
+func (b *builder) writer(output Output) (io.Writer, error) {
+	switch output.Type {
+	case OutputStdout:
+		return os.Stdout, nil
+	case OutputStderr, "":
+		return os.Stderr, nil
+	case OutputFile:
+		if output.Path == "" {
+			return nil, errors.New("file path is not specified")
+		}
+
+		file, err := openRotatingFile(output.Path, output.MaxSize, output.MaxBackups)
+		if err != nil {
+			return nil, err
+		}
+
+		b.closers = append(b.closers, file)
+
+		return file, nil
+	case OutputNetwork:
+		writer, err := slogxnet.NewWriter(output.Network, output.Address, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		b.closers = append(b.closers, writer)
+
+		return writer, nil
+	default:
+		return nil, fmt.Errorf("unknown output type %q", output.Type)
+	}
+}
+
+func (b *builder) close() error {
+	var errs []error
+
+	for _, closer := range b.closers {
+		err := closer.Close()
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	b.closers = nil
+
+	return errors.Join(errs...)
+}
+
+// ---
+
+func applyEnv(config Config) Config {
+	if level := os.Getenv(EnvLevel); level != "" {
+		config.Level = level
+	}
+
+	if format := os.Getenv(EnvFormat); format != "" {
+		config.Format = format
+		config.Outputs = slices.Clone(config.Outputs)
+
+		for i := range config.Outputs {
+			config.Outputs[i].Format = ""
+		}
+	}
+
+	return config
+}
+
+func parseLevel(s string, fallback slog.Level) (slog.Level, error) {
+	if s == "" {
+		return fallback, nil
+	}
+
+	return slogx.ParseLevel(s)
+}
+
+func redactionRules(config *Redaction) ([]slogx.RedactionRule, error) {
+	redactor := slogx.MaskRedactor(cmp.Or(config.Mask, "***"))
+	if config.Hash {
+		redactor = slogx.HashRedactor(config.Salt)
+	}
+
+	var rules []slogx.RedactionRule
+
+	if len(config.Keys) != 0 {
+		rules = append(rules, slogx.RedactKeys(redactor, config.Keys...))
+	}
+
+	for _, path := range config.Paths {
+		rules = append(rules, slogx.RedactPath(redactor, strings.Split(path, ".")...))
+	}
+
+	for _, pattern := range config.Patterns {
+		re, ok := namedPatterns[pattern]
+		if !ok {
+			var err error
+
+			re, err = regexp.Compile(pattern)
+			if err != nil {
+				return nil, fmt.Errorf("slogxconf: redaction pattern %q: %w", pattern, err)
+			}
+		}
+
+		rules = append(rules, slogx.RedactPattern(redactor, re))
+	}
+
+	return rules, nil
+}
+
+func staticAttrs(values map[string]any) []slog.Attr {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+
+	slices.Sort(keys)
+
+	attrs := make([]slog.Attr, len(keys))
+	for i, key := range keys {
+		attrs[i] = slog.Any(key, values[key])
+	}
+
+	return attrs
+}
+
+var namedPatterns = map[string]*regexp.Regexp{
+	"email":  slogx.EmailPattern,
+	"card":   slogx.CardNumberPattern,
+	"bearer": slogx.BearerTokenPattern,
+}
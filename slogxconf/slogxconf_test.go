@@ -0,0 +1,290 @@
+package slogxconf_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	. "github.com/pamburus/go-tst/tst"
+	"github.com/pamburus/slogx"
+	"github.com/pamburus/slogx/slogc"
+	"github.com/pamburus/slogx/slogxconf"
+)
+
+func TestNew(tt *testing.T) {
+	t := New(tt)
+
+	readJSON := func(t Test, path string) []map[string]any {
+		data, err := os.ReadFile(path)
+		t.Expect(err).ToNot(HaveOccurred())
+
+		var result []map[string]any
+
+		for _, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+			var record map[string]any
+			t.Expect(json.Unmarshal([]byte(line), &record)).ToNot(HaveOccurred())
+			delete(record, slog.TimeKey)
+
+			result = append(result, record)
+		}
+
+		return result
+	}
+
+	t.Run("File", func(t Test) {
+		dir := tt.TempDir()
+
+		var config slogxconf.Config
+		t.Expect(json.Unmarshal([]byte(`{
+			"level": "info",
+			"outputs": [
+				{"type": "file", "path": "`+filepath.Join(dir, "all.log")+`"},
+				{"type": "file", "path": "`+filepath.Join(dir, "warn.log")+`", "level": "warn"}
+			],
+			"names": {"db": "debug", "http": "error"},
+			"nameKey": "logger",
+			"redaction": {"keys": ["password"], "paths": ["user.token"], "patterns": ["email"]},
+			"attrs": {"service": "s1", "env": "test"}
+		}`), &config)).ToNot(HaveOccurred())
+
+		logger, closeLogger, err := slogxconf.New(config)
+		t.Expect(err).ToNot(HaveOccurred())
+
+		ctx := context.Background()
+		logger.Debug("m1")
+		logger.Info("m2", slog.String("password", "p"), slog.Group("user", slog.String("token", "t"), slog.String("email", "a@b.com")))
+		logger.ContextLogger().Debug(slogc.WithName(ctx, "db"), "m3")
+		logger.ContextLogger().Warn(slogc.WithName(ctx, "http"), "m4")
+		logger.Log(slogx.LevelNotice, "m5")
+		logger.Warn("m6")
+
+		t.Expect(closeLogger()).ToNot(HaveOccurred())
+
+		t.Expect(readJSON(t, filepath.Join(dir, "all.log"))).To(Equal([]map[string]any{
+			{"level": "INFO", "msg": "m2", "env": "test", "service": "s1", "password": "***", "user": map[string]any{"token": "***", "email": "***"}},
+			{"level": "DEBUG", "msg": "m3", "env": "test", "service": "s1", "logger": "db"},
+			{"level": "NOTICE", "msg": "m5", "env": "test", "service": "s1"},
+			{"level": "WARN", "msg": "m6", "env": "test", "service": "s1"},
+		}))
+
+		t.Expect(readJSON(t, filepath.Join(dir, "warn.log"))).To(Equal([]map[string]any{
+			{"level": "WARN", "msg": "m6", "env": "test", "service": "s1"},
+		}))
+	})
+
+	t.Run("SingleOutputLevel", func(t Test) {
+		path := filepath.Join(tt.TempDir(), "warn.log")
+
+		logger, closeLogger, err := slogxconf.New(slogxconf.Config{
+			Level:   "info",
+			Outputs: []slogxconf.Output{{Type: slogxconf.OutputFile, Path: path, Level: "warn"}},
+			Names:   map[string]string{"db": "debug"},
+		})
+		t.Expect(err).ToNot(HaveOccurred())
+
+		ctx := context.Background()
+		logger.Info("m1")
+		logger.ContextLogger().Info(slogc.WithName(ctx, "db"), "m2")
+		logger.Warn("m3")
+		t.Expect(closeLogger()).ToNot(HaveOccurred())
+
+		t.Expect(readJSON(t, path)).To(Equal([]map[string]any{
+			{"level": "WARN", "msg": "m3"},
+		}))
+	})
+
+	t.Run("Console", func(t Test) {
+		path := filepath.Join(tt.TempDir(), "console.log")
+
+		logger, closeLogger, err := slogxconf.New(slogxconf.Config{
+			Format:  slogxconf.FormatConsole,
+			Source:  true,
+			Outputs: []slogxconf.Output{{Type: slogxconf.OutputFile, Path: path}},
+		})
+		t.Expect(err).ToNot(HaveOccurred())
+
+		logger.WithGroup("g").Info("m1", slog.Int("a", 1))
+		logger.Warn("m2")
+		t.Expect(closeLogger()).ToNot(HaveOccurred())
+
+		data, err := os.ReadFile(path)
+		t.Expect(err).ToNot(HaveOccurred())
+
+		lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+		t.Expect(lines).To(HaveLen(2))
+		t.Expect(regexp.MustCompile(`^\d{4}-\d\d-\d\d \d\d:\d\d:\d\d\.\d{3} INFO  slogxconf_test\.go:\d+ > m1 g\.a=1$`).MatchString(lines[0])).To(BeTrue())
+		t.Expect(regexp.MustCompile(`^\d{4}-\d\d-\d\d \d\d:\d\d:\d\d\.\d{3} WARN  slogxconf_test\.go:\d+ > m2$`).MatchString(lines[1])).To(BeTrue())
+	})
+
+	t.Run("Rotation", func(t Test) {
+		path := filepath.Join(tt.TempDir(), "app.log")
+
+		logger, closeLogger, err := slogxconf.New(slogxconf.Config{
+			Format:  slogxconf.FormatText,
+			Outputs: []slogxconf.Output{{Type: slogxconf.OutputFile, Path: path, MaxSize: 250, MaxBackups: 2}},
+		})
+		t.Expect(err).ToNot(HaveOccurred())
+
+		for range 10 {
+			logger.Info("message", slog.String("padding", strings.Repeat("x", 40)))
+		}
+
+		t.Expect(closeLogger()).ToNot(HaveOccurred())
+
+		for _, name := range []string{path, path + ".1", path + ".2"} {
+			info, err := os.Stat(name)
+			t.Expect(err).ToNot(HaveOccurred())
+			t.Expect(info.Size()).To(BeGreaterThan(int64(0)))
+			t.Expect(info.Size()).To(BeLessOrEqualThan(int64(250)))
+		}
+
+		_, err = os.Stat(path + ".3")
+		t.Expect(os.IsNotExist(err)).To(BeTrue())
+	})
+
+	t.Run("RotationFailure", func(t Test) {
+		path := filepath.Join(tt.TempDir(), "app.log")
+		t.Expect(os.MkdirAll(filepath.Join(path+".1", "x"), 0o755)).ToNot(HaveOccurred())
+
+		logger, closeLogger, err := slogxconf.New(slogxconf.Config{
+			Outputs: []slogxconf.Output{{Type: slogxconf.OutputFile, Path: path, MaxSize: 100, MaxBackups: 1}},
+		})
+		t.Expect(err).ToNot(HaveOccurred())
+
+		for i := range 3 {
+			logger.Info("message", slog.Int("i", i))
+		}
+
+		t.Expect(closeLogger()).ToNot(HaveOccurred())
+		t.Expect(readJSON(t, path)).To(Equal([]map[string]any{
+			{"level": "INFO", "msg": "message", "i": float64(0)},
+			{"level": "INFO", "msg": "message", "i": float64(1)},
+			{"level": "INFO", "msg": "message", "i": float64(2)},
+		}))
+	})
+
+	t.Run("Network", func(t Test) {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		t.Expect(err).ToNot(HaveOccurred())
+
+		defer listener.Close()
+
+		lines := make(chan string, 1)
+
+		go func() {
+			conn, err := listener.Accept()
+			if err != nil {
+				close(lines)
+
+				return
+			}
+
+			defer conn.Close()
+
+			scanner := bufio.NewScanner(conn)
+			for scanner.Scan() {
+				lines <- scanner.Text()
+			}
+
+			close(lines)
+		}()
+
+		logger, closeLogger, err := slogxconf.New(slogxconf.Config{
+			Outputs: []slogxconf.Output{{Type: slogxconf.OutputNetwork, Network: "tcp", Address: listener.Addr().String()}},
+		})
+		t.Expect(err).ToNot(HaveOccurred())
+
+		logger.Info("m1")
+		t.Expect(closeLogger()).ToNot(HaveOccurred())
+
+		var record map[string]any
+		t.Expect(json.Unmarshal([]byte(<-lines), &record)).ToNot(HaveOccurred())
+		t.Expect(record["msg"]).To(Equal("m1"))
+	})
+
+	t.Run("NetworkDown", func(t Test) {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		t.Expect(err).ToNot(HaveOccurred())
+
+		address := listener.Addr().String()
+		t.Expect(listener.Close()).ToNot(HaveOccurred())
+
+		logger, closeLogger, err := slogxconf.New(slogxconf.Config{
+			Format:  slogxconf.FormatText,
+			Outputs: []slogxconf.Output{{Type: slogxconf.OutputNetwork, Network: "tcp", Address: address}},
+		})
+		t.Expect(err).ToNot(HaveOccurred())
+
+		logger.Info("m1")
+
+		listener, err = net.Listen("tcp", address)
+		t.Expect(err).ToNot(HaveOccurred())
+
+		defer listener.Close()
+
+		lines := make(chan string, 1)
+
+		go func() {
+			defer close(lines)
+
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+
+			defer conn.Close()
+
+			scanner := bufio.NewScanner(conn)
+			for scanner.Scan() {
+				lines <- scanner.Text()
+			}
+		}()
+
+		t.Expect(closeLogger()).ToNot(HaveOccurred())
+		t.Expect(strings.HasSuffix(<-lines, " level=INFO msg=m1")).To(BeTrue())
+	})
+
+	t.Run("Errors", func(t Test) {
+		for _, config := range []slogxconf.Config{
+			{Level: "verbose"},
+			{Format: "xml"},
+			{Outputs: []slogxconf.Output{{Type: "pipe"}}},
+			{Outputs: []slogxconf.Output{{Type: slogxconf.OutputFile}}},
+			{Outputs: []slogxconf.Output{{Level: "x"}}},
+			{Names: map[string]string{"a": "x"}},
+			{Redaction: &slogxconf.Redaction{Patterns: []string{"("}}},
+		} {
+			_, _, err := slogxconf.New(config)
+			t.Expect(err).To(HaveOccurred())
+		}
+	})
+}
+
+func TestEnv(tt *testing.T) {
+	t := New(tt)
+
+	path := filepath.Join(tt.TempDir(), "app.log")
+
+	tt.Setenv(slogxconf.EnvLevel, "debug")
+	tt.Setenv(slogxconf.EnvFormat, "text")
+
+	logger, closeLogger, err := slogxconf.New(slogxconf.Config{
+		Level:   "error",
+		Outputs: []slogxconf.Output{{Type: slogxconf.OutputFile, Path: path, Format: slogxconf.FormatJSON}},
+	})
+	t.Expect(err).ToNot(HaveOccurred())
+
+	logger.Debug("m1")
+	t.Expect(closeLogger()).ToNot(HaveOccurred())
+
+	data, err := os.ReadFile(path)
+	t.Expect(err).ToNot(HaveOccurred())
+	t.Expect(strings.HasSuffix(string(data), " level=DEBUG msg=m1\n")).To(BeTrue())
+}
@@ -0,0 +1,9 @@
+//go:build !unix
+
+package slogxjournal
+
+func (h *handler) send(data []byte) error {
+	_, _, err := h.conn.WriteMsgUnix(data, nil, h.addr)
+
+	return err
+}
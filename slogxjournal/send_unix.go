@@ -0,0 +1,49 @@
+//go:build unix
+
+package slogxjournal
+
+import (
+	"errors"
+	"os"
+	"syscall"
+)
+
+// send sends the entry as a datagram, falling back to [handler.sendFile] if it is too large.
+func (h *handler) send(data []byte) error {
+	_, _, err := h.conn.WriteMsgUnix(data, nil, h.addr)
+	if errors.Is(err, syscall.EMSGSIZE) || errors.Is(err, syscall.ENOBUFS) {
+		return h.sendFile(data)
+	}
+
+	return err
+}
+
+// sendFile sends the entry that is too large for a datagram by writing it to an unlinked temporary file
+// and passing its descriptor to journald, as the native protocol specifies.
+func (h *handler) sendFile(data []byte) error {
+	dir := "/dev/shm"
+	if _, err := os.Stat(dir); err != nil {
+		dir = os.TempDir()
+	}
+
+	file, err := os.CreateTemp(dir, "slogxjournal-")
+	if err != nil {
+		return err
+	}
+
+	defer file.Close()
+
+	err = os.Remove(file.Name())
+	if err != nil {
+		return err
+	}
+
+	_, err = file.Write(data)
+	if err != nil {
+		return err
+	}
+
+	_, _, err = h.conn.WriteMsgUnix(nil, syscall.UnixRights(int(file.Fd())), h.addr)
+
+	return err
+}
@@ -0,0 +1,267 @@
+// Package slogxjournal provides a [slog.Handler] sending log records to the systemd journal
+// using the journald native protocol.
+package slogxjournal
+
+import (
+	"context"
+	"encoding/binary"
+	"log/slog"
+	"net"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/pamburus/slogx"
+	"github.com/pamburus/slogx/slogc"
+	"github.com/pamburus/slogx/slogxsyslog"
+)
+
+// NewHandler returns a new [slog.Handler] sending each log record as a journal entry
+// to the journald native protocol socket.
+// Attributes are sent as fields with uppercase names, see [FieldName], with groups flattened
+// into underscore-separated names. The message is sent as MESSAGE, the level is sent as PRIORITY,
+// see [slogxsyslog.SeverityForLevel], and the logger name set using [slogc.WithName] is sent as SYSLOG_IDENTIFIER.
+// See [HandlerOptions] for the available options, nil options mean default options.
+func NewHandler(options *HandlerOptions) (slog.Handler, error) {
+	if options == nil {
+		options = &HandlerOptions{}
+	}
+
+	opts := *options
+
+	if opts.Socket == "" {
+		opts.Socket = DefaultSocket
+	}
+
+	if opts.Identifier == "" && len(os.Args) != 0 {
+		opts.Identifier = filepath.Base(os.Args[0])
+	}
+
+	conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Net: "unixgram"})
+	if err != nil {
+		return nil, err
+	}
+
+	return &handler{
+		shared: &shared{
+			conn: conn,
+			addr: &net.UnixAddr{Name: opts.Socket, Net: "unixgram"},
+		},
+		options: opts,
+	}, nil
+}
+
+// FieldName converts an attribute key to a valid journal field name.
+// Letters are converted to upper case, characters other than letters, digits and underscores are replaced with underscores,
+// leading underscores are removed since they denote trusted fields, names starting with a digit are prefixed with "X",
+// and names are truncated to 64 characters.
+// Names of the fields set by the handler itself, which are MESSAGE, PRIORITY, SYSLOG_IDENTIFIER and any name starting with CODE_,
+// are prefixed with "X_", so attributes cannot override them.
+func FieldName(key string) string {
+	return string(appendFieldName(nil, key))
+}
+
+// ---
+
+// HandlerOptions contains options for [NewHandler].
+type HandlerOptions struct {
+	// Level is the minimum level of records to send, [slog.LevelInfo] is used if it is nil.
+	Level slog.Leveler
+	// AddSource specifies whether to send the source location of the record as CODE_FILE, CODE_LINE and CODE_FUNC.
+	AddSource bool
+	// Identifier is the SYSLOG_IDENTIFIER for records without a logger name set using [slogc.WithName],
+	// the base name of the executable is used if it is empty.
+	Identifier string
+	// Socket is the path of the journald native protocol socket, [DefaultSocket] is used if it is empty.
+	Socket string
+}
+
+// DefaultSocket is the default path of the journald native protocol socket.
+const DefaultSocket = "/run/systemd/journal/socket"
+
+// Names of the fields set by the handler.
+const (
+	FieldMessage          = "MESSAGE"
+	FieldPriority         = "PRIORITY"
+	FieldCodeFile         = "CODE_FILE"
+	FieldCodeLine         = "CODE_LINE"
+	FieldCodeFunc         = "CODE_FUNC"
+	FieldSyslogIdentifier = "SYSLOG_IDENTIFIER"
+)
+
+// ---
+
+type handler struct {
+	*shared
+	options HandlerOptions
+	prefix  string
+	attrs   []byte
+}
+
+type shared struct {
+	conn *net.UnixConn
+	addr *net.UnixAddr
+}
+
+func (h *handler) Enabled(_ context.Context, level slog.Level) bool {
+	minLevel := slog.LevelInfo
+	if h.options.Level != nil {
+		minLevel = h.options.Level.Level()
+	}
+
+	return level >= minLevel
+}
+
+func (h *handler) Handle(ctx context.Context, record slog.Record) error {
+	buf := make([]byte, 0, 256+len(h.attrs))
+	buf = appendField(buf, FieldMessage, record.Message)
+	buf = appendField(buf, FieldPriority, strconv.Itoa(int(slogxsyslog.SeverityForLevel(record.Level))))
+
+	identifier := slogc.Name(ctx)
+	if identifier == "" {
+		identifier = h.options.Identifier
+	}
+
+	if identifier != "" {
+		buf = appendField(buf, FieldSyslogIdentifier, identifier)
+	}
+
+	if h.options.AddSource && record.PC != 0 {
+		frame, _ := runtime.CallersFrames([]uintptr{record.PC}).Next()
+		buf = appendField(buf, FieldCodeFile, frame.File)
+		buf = appendField(buf, FieldCodeLine, strconv.Itoa(frame.Line))
+		buf = appendField(buf, FieldCodeFunc, frame.Function)
+	}
+
+	buf = append(buf, h.attrs...)
+
+	record.Attrs(func(attr slog.Attr) bool {
+		buf = appendAttr(buf, h.prefix, attr)
+
+		return true
+	})
+
+	return h.send(buf)
+}
+
+func (h *handler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+
+	h2 := *h
+	h2.attrs = slices.Clip(h.attrs)
+	for _, attr := range attrs {
+		h2.attrs = appendAttr(h2.attrs, h.prefix, attr)
+	}
+
+	return &h2
+}
+
+func (h *handler) WithGroup(key string) slog.Handler {
+	if key == "" {
+		return h
+	}
+
+	h2 := *h
+	h2.prefix = h.prefix + key + "_"
+
+	return &h2
+}
+
+func (h *handler) Close(context.Context) error {
+	return h.conn.Close()
+}
+
+// ---
+
+func appendAttr(buf []byte, prefix string, attr slog.Attr) []byte {
+	value := attr.Value.Resolve()
+
+	if value.Kind() == slog.KindGroup {
+		if attr.Key != "" {
+			prefix += attr.Key + "_"
+		}
+
+		for _, attr := range value.Group() {
+			buf = appendAttr(buf, prefix, attr)
+		}
+
+		return buf
+	}
+
+	if attr.Key == "" {
+		return buf
+	}
+
+	return appendField(buf, string(appendFieldName(nil, prefix+attr.Key)), value.String())
+}
+
+// appendField appends a field in the journald native protocol format,
+// which is NAME=value followed by a newline for values without newlines,
+// or NAME followed by a newline, the little-endian 64-bit length of the value, the value and a newline otherwise.
+func appendField(buf []byte, name, value string) []byte {
+	buf = append(buf, name...)
+
+	if strings.IndexByte(value, '\n') < 0 {
+		buf = append(buf, '=')
+		buf = append(buf, value...)
+	} else {
+		buf = append(buf, '\n')
+		buf = binary.LittleEndian.AppendUint64(buf, uint64(len(value)))
+		buf = append(buf, value...)
+	}
+
+	return append(buf, '\n')
+}
+
+func appendFieldName(buf []byte, key string) []byte {
+	key = strings.TrimLeft(key, "_")
+
+	start := len(buf)
+	if key == "" || (key[0] >= '0' && key[0] <= '9') {
+		buf = append(buf, 'X')
+	}
+
+	for i := 0; i < len(key) && len(buf)-start < maxFieldNameLen; i++ {
+		c := key[i]
+
+		switch {
+		case c >= 'a' && c <= 'z':
+			c -= 'a' - 'A'
+		case c >= 'A' && c <= 'Z', c >= '0' && c <= '9', c == '_':
+		default:
+			c = '_'
+		}
+
+		buf = append(buf, c)
+	}
+
+	if isReservedFieldName(string(buf[start:])) {
+		buf = slices.Insert(buf, start, []byte(reservedFieldPrefix)...)
+		buf = buf[:min(len(buf), start+maxFieldNameLen)]
+	}
+
+	return buf
+}
+
+func isReservedFieldName(name string) bool {
+	switch name {
+	case FieldMessage, FieldPriority, FieldSyslogIdentifier:
+		return true
+	}
+
+	return strings.HasPrefix(name, "CODE_")
+}
+
+const (
+	maxFieldNameLen     = 64
+	reservedFieldPrefix = "X_"
+)
+
+// ---
+
+var _ slogx.Closer = (*handler)(nil)
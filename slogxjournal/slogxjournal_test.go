@@ -0,0 +1,212 @@
+package slogxjournal_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"io"
+	"log/slog"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	. "github.com/pamburus/go-tst/tst"
+	"github.com/pamburus/slogx"
+	"github.com/pamburus/slogx/slogc"
+	"github.com/pamburus/slogx/slogxjournal"
+)
+
+func TestHandler(tt *testing.T) {
+	t := New(tt)
+	ctx := context.Background()
+
+	setup := func(options slogxjournal.HandlerOptions) (*journal, slog.Handler) {
+		j := newJournal(t, filepath.Join(tt.TempDir(), "socket"))
+		options.Socket = j.path
+
+		handler, err := slogxjournal.NewHandler(&options)
+		t.Expect(err).ToNot(HaveOccurred())
+
+		return j, handler
+	}
+
+	t.Run("Fields", func(t Test) {
+		j, handler := setup(slogxjournal.HandlerOptions{Identifier: "app1"})
+
+		defer j.Close()
+		defer slogx.Close(ctx, handler)
+
+		logger := slogx.NewContextLogger(handler).WithSource(false).With(slog.String("service-name", "s1")).WithGroup("http")
+		logger.Warn(ctx, "m1\nm2", slog.Int("status", 500), slog.Group("req", slog.String("path", "/a")), slog.Group("", slog.Int("n", 1)))
+		logger.Debug(ctx, "m3")
+		logger.Error(slogc.WithName(ctx, "db"), "m4")
+
+		t.Expect(j.read(t)).To(Equal([]string{
+			"MESSAGE=m1\nm2",
+			"PRIORITY=4",
+			"SYSLOG_IDENTIFIER=app1",
+			"SERVICE_NAME=s1",
+			"HTTP_STATUS=500",
+			"HTTP_REQ_PATH=/a",
+			"HTTP_N=1",
+		}))
+		t.Expect(j.read(t)).To(Equal([]string{
+			"MESSAGE=m4",
+			"PRIORITY=3",
+			"SYSLOG_IDENTIFIER=db",
+			"SERVICE_NAME=s1",
+		}))
+	})
+
+	t.Run("Source", func(t Test) {
+		j, handler := setup(slogxjournal.HandlerOptions{AddSource: true, Level: slog.LevelDebug})
+
+		defer j.Close()
+		defer slogx.Close(ctx, handler)
+
+		slogx.New(handler).Debug("m1")
+
+		fields := j.read(t)
+		t.Expect(fields).To(HaveLen(6))
+		t.Expect(fields[:2]).To(Equal([]string{"MESSAGE=m1", "PRIORITY=7"}))
+		t.Expect(strings.HasSuffix(fields[3], "/slogxjournal_test.go")).To(BeTrue())
+		t.Expect(strings.HasPrefix(fields[4], "CODE_LINE=")).To(BeTrue())
+		t.Expect(fields[5]).To(Equal("CODE_FUNC=github.com/pamburus/slogx/slogxjournal_test.TestHandler.func3"))
+	})
+
+	t.Run("Reserved", func(t Test) {
+		j, handler := setup(slogxjournal.HandlerOptions{Identifier: "app1"})
+
+		defer j.Close()
+		defer slogx.Close(ctx, handler)
+
+		slogx.New(handler).WithSource(false).Info("m1", slog.String("message", "m2"), slog.Group("code", slog.Int("line", 1)))
+
+		t.Expect(j.read(t)).To(Equal([]string{
+			"MESSAGE=m1",
+			"PRIORITY=6",
+			"SYSLOG_IDENTIFIER=app1",
+			"X_MESSAGE=m2",
+			"X_CODE_LINE=1",
+		}))
+	})
+
+	t.Run("Large", func(t Test) {
+		j, handler := setup(slogxjournal.HandlerOptions{Identifier: "app1"})
+
+		defer j.Close()
+		defer slogx.Close(ctx, handler)
+
+		large := strings.Repeat("x", 1<<20)
+		slogx.New(handler).Info("m1", slog.String("data", large))
+
+		t.Expect(j.read(t)).To(Equal([]string{
+			"MESSAGE=m1",
+			"PRIORITY=6",
+			"SYSLOG_IDENTIFIER=app1",
+			"DATA=" + large,
+		}))
+	})
+
+	t.Run("Failure", func(t Test) {
+		handler, err := slogxjournal.NewHandler(&slogxjournal.HandlerOptions{Socket: filepath.Join(tt.TempDir(), "missing")})
+		t.Expect(err).ToNot(HaveOccurred())
+
+		t.Expect(handler.Handle(ctx, slog.NewRecord(time.Now(), slog.LevelInfo, "m1", 0))).To(HaveOccurred())
+		t.Expect(slogx.Close(ctx, handler)).ToNot(HaveOccurred())
+	})
+}
+
+func TestFieldName(tt *testing.T) {
+	t := New(tt)
+
+	for key, name := range map[string]string{
+		"message":                         "X_MESSAGE",
+		"_priority":                       "X_PRIORITY",
+		"syslog.identifier":               "X_SYSLOG_IDENTIFIER",
+		"code.file":                       "X_CODE_FILE",
+		"code_owner":                      "X_CODE_OWNER",
+		"codec":                           "CODEC",
+		"message.id":                      "MESSAGE_ID",
+		"http.status-code":                "HTTP_STATUS_CODE",
+		"__trusted":                       "TRUSTED",
+		"1st":                             "X1ST",
+		"":                                "X",
+		"ключ":                            "________",
+		strings.Repeat("a", 70):           strings.Repeat("A", 64),
+		"code_" + strings.Repeat("a", 70): "X_CODE_" + strings.Repeat("A", 57),
+	} {
+		t.Expect(slogxjournal.FieldName(key)).To(Equal(name))
+	}
+}
+
+// ---
+
+// journal is a fake journald listening on a unix datagram socket.
+type journal struct {
+	*net.UnixConn
+	path string
+}
+
+func newJournal(t Test, path string) *journal {
+	conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: path, Net: "unixgram"})
+	t.Expect(err).ToNot(HaveOccurred())
+
+	return &journal{conn, path}
+}
+
+// read receives an entry, reading it from the passed file descriptor if the datagram is empty,
+// and parses its fields.
+func (j *journal) read(t Test) []string {
+	buf := make([]byte, 1<<16)
+	oob := make([]byte, 64)
+
+	n, oobn, _, _, err := j.ReadMsgUnix(buf, oob)
+	t.Expect(err).ToNot(HaveOccurred())
+
+	data := buf[:n]
+
+	if oobn != 0 {
+		messages, err := syscall.ParseSocketControlMessage(oob[:oobn])
+		t.Expect(err).ToNot(HaveOccurred())
+		t.Expect(messages).To(HaveLen(1))
+
+		fds, err := syscall.ParseUnixRights(&messages[0])
+		t.Expect(err).ToNot(HaveOccurred())
+		t.Expect(fds).To(HaveLen(1))
+
+		file := os.NewFile(uintptr(fds[0]), "entry")
+		defer file.Close()
+
+		_, err = file.Seek(0, io.SeekStart)
+		t.Expect(err).ToNot(HaveOccurred())
+
+		data, err = io.ReadAll(file)
+		t.Expect(err).ToNot(HaveOccurred())
+	}
+
+	var fields []string
+
+	for len(data) != 0 {
+		i := bytes.IndexByte(data, '\n')
+		t.Expect(i).To(BeGreaterThan(0))
+
+		line := string(data[:i])
+		data = data[i+1:]
+
+		if !strings.Contains(line, "=") {
+			size := binary.LittleEndian.Uint64(data)
+			line += "=" + string(data[8:8+size])
+			t.Expect(data[8+size]).To(Equal(byte('\n')))
+			data = data[9+size:]
+		}
+
+		fields = append(fields, line)
+	}
+
+	return fields
+}
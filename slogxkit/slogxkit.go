@@ -0,0 +1,159 @@
+// Package slogxkit exposes [slogx.Logger] through the go-kit log.Logger interface.
+//
+// The package does not depend on go-kit modules, it relies on the structural compatibility
+// of the Log(keyvals ...any) error method with the go-kit log.Logger interface.
+package slogxkit
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"runtime"
+	"strings"
+
+	"github.com/pamburus/slogx"
+)
+
+// New returns a new [Logger] that sends log records to the logger.
+func New(logger *slogx.Logger) *Logger {
+	return &Logger{logger}
+}
+
+// ---
+
+// Logger implements the go-kit log.Logger interface on top of [slogx.Logger].
+//
+// Each call to [Logger.Log] produces a log record.
+// The value of the [MessageKey] key is used as the message.
+// The value of the [LevelKey] key, as set by the go-kit log/level package, is used as the level,
+// [slog.LevelInfo] is used if the level is missing or unknown.
+// Other key-value pairs are added as attributes.
+// The source location is determined by skipping the frames of the go-kit log packages.
+type Logger struct {
+	logger *slogx.Logger
+}
+
+// Log logs the key-value pairs.
+func (l *Logger) Log(keyvals ...any) error {
+	ctx := context.Background()
+	level := slog.LevelInfo
+	msg := ""
+
+	attrs := make([]slog.Attr, 0, (len(keyvals)+1)/2)
+
+	for i := 0; i < len(keyvals); i += 2 {
+		key := fmt.Sprint(keyvals[i])
+
+		var value any = missingValue
+		if i+1 < len(keyvals) {
+			value = keyvals[i+1]
+		}
+
+		switch key {
+		case LevelKey:
+			if lvl, ok := parseLevel(value); ok {
+				level = lvl
+
+				continue
+			}
+		case MessageKey:
+			if s, ok := value.(string); ok && msg == "" {
+				msg = s
+
+				continue
+			}
+		}
+
+		attrs = append(attrs, slog.Any(key, value))
+	}
+
+	if l.logger.Enabled(ctx, level) {
+		l.logger.LogWithPC(ctx, callerPC(), level, msg, attrs...)
+	}
+
+	return nil
+}
+
+// With returns a new [Logger] with the key-value pairs added as attributes using [slogx.Logger.With],
+// so they are buffered in the logger instead of being applied to the handler immediately.
+func (l *Logger) With(keyvals ...any) *Logger {
+	attrs := make([]slog.Attr, 0, (len(keyvals)+1)/2)
+
+	for i := 0; i < len(keyvals); i += 2 {
+		var value any = missingValue
+		if i+1 < len(keyvals) {
+			value = keyvals[i+1]
+		}
+
+		attrs = append(attrs, slog.Any(fmt.Sprint(keyvals[i]), value))
+	}
+
+	return &Logger{l.logger.With(attrs...)}
+}
+
+// ---
+
+// Keys having special meaning in key-value pairs passed to [Logger.Log].
+const (
+	LevelKey   = "level"
+	MessageKey = "msg"
+)
+
+// ---
+
+func parseLevel(value any) (slog.Level, bool) {
+	var name string
+
+	switch value := value.(type) {
+	case string:
+		name = value
+	case fmt.Stringer:
+		name = value.String()
+	default:
+		return 0, false
+	}
+
+	switch strings.ToLower(name) {
+	case "debug":
+		return slog.LevelDebug, true
+	case "info":
+		return slog.LevelInfo, true
+	case "warn", "warning":
+		return slog.LevelWarn, true
+	case "error":
+		return slog.LevelError, true
+	default:
+		return 0, false
+	}
+}
+
+func callerPC() uintptr {
+	var pcs [16]uintptr
+
+	n := runtime.Callers(3, pcs[:])
+	for _, pc := range pcs[:n] {
+		fn := runtime.FuncForPC(pc - 1)
+		if fn == nil || !isKitFunction(fn.Name()) {
+			return pc
+		}
+	}
+
+	return 0
+}
+
+func isKitFunction(name string) bool {
+	for _, prefix := range kitPackages {
+		if rest, ok := strings.CutPrefix(name, prefix); ok && rest != "" && (rest[0] == '.' || rest[0] == '/') {
+			return true
+		}
+	}
+
+	return false
+}
+
+var kitPackages = []string{
+	"github.com/go-kit/log",
+	"github.com/go-kit/kit/log",
+}
+
+const missingValue = "(MISSING)"
@@ -0,0 +1,87 @@
+package slogxkit_test
+
+import (
+	"log/slog"
+	"runtime"
+	"testing"
+
+	. "github.com/pamburus/go-tst/tst"
+	"github.com/pamburus/slogx"
+	"github.com/pamburus/slogx/internal/mock"
+	"github.com/pamburus/slogx/slogxkit"
+)
+
+func TestLogger(tt *testing.T) {
+	t := New(tt)
+
+	setup := func() (*mock.CallLog, *slogxkit.Logger) {
+		cl := mock.NewCallLog()
+		handler := slogx.TweakHandler(mock.NewHandler(cl)).WithLevel(slog.LevelInfo).Result()
+
+		return cl, slogxkit.New(slogx.New(handler))
+	}
+
+	records := func(cl *mock.CallLog) []mock.Record {
+		var result []mock.Record
+
+		for _, call := range cl.Calls().WithoutTime() {
+			if call, ok := call.(mock.HandlerHandle); ok {
+				result = append(result, call.Record)
+			}
+		}
+
+		return result
+	}
+
+	t.Run("Log", func(t Test) {
+		cl, logger := setup()
+
+		t.Expect(logger.Log("msg", "m1", "a", 1)).ToNot(HaveOccurred())
+		t.Expect(logger.Log("level", level("warn"), "msg", "m2", "b")).ToNot(HaveOccurred())
+		t.Expect(logger.Log("level", "debug", "msg", "m3")).ToNot(HaveOccurred())
+		t.Expect(logger.Log("level", "custom", 42, true)).ToNot(HaveOccurred())
+
+		result := records(cl)
+		t.Expect(result).To(HaveLen(3))
+		t.Expect(functionOf(result[0].PC)).To(Equal("github.com/pamburus/slogx/slogxkit_test.TestLogger.func3"))
+
+		for i := range result {
+			result[i].PC = 0
+		}
+
+		t.Expect(result).To(Equal([]mock.Record{
+			{Level: slog.LevelInfo, Message: "m1", Attrs: []mock.Attr{{Key: "a", Value: int64(1)}}},
+			{Level: slog.LevelWarn, Message: "m2", Attrs: []mock.Attr{{Key: "b", Value: "(MISSING)"}}},
+			{Level: slog.LevelInfo, Attrs: []mock.Attr{{Key: "level", Value: "custom"}, {Key: "42", Value: true}}},
+		}))
+	})
+
+	t.Run("With", func(t Test) {
+		cl, logger := setup()
+
+		t.Expect(logger.With("a", 1).Log("msg", "m1")).ToNot(HaveOccurred())
+
+		for _, call := range cl.Calls() {
+			_, ok := call.(mock.HandlerWithAttrs)
+			t.Expect(ok).To(BeFalse())
+		}
+
+		result := records(cl)
+		t.Expect(result).To(HaveLen(1))
+		t.Expect(result[0].Attrs).To(Equal([]mock.Attr{{Key: "a", Value: int64(1)}}))
+	})
+}
+
+// ---
+
+type level string
+
+func (l level) String() string {
+	return string(l)
+}
+
+func functionOf(pc uintptr) string {
+	frame, _ := runtime.CallersFrames([]uintptr{pc}).Next()
+
+	return frame.Function
+}
@@ -0,0 +1,143 @@
+// Package slogxlog provides a bridge from the standard [log] package to [slogx].
+package slogxlog
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"log/slog"
+	"runtime"
+	"strings"
+
+	"github.com/pamburus/slogx"
+)
+
+// NewWriter returns a new [Writer] that sends lines written by a [log.Logger] to the logger.
+// See [Options] for the available options, nil options mean default options.
+func NewWriter(logger *slogx.Logger, options *Options) *Writer {
+	if options == nil {
+		options = &Options{}
+	}
+
+	return &Writer{logger, *options}
+}
+
+// NewLogger returns a new [log.Logger] that sends its output to the logger.
+// It is useful for libraries accepting a [log.Logger], for example [net/http.Server].
+func NewLogger(logger *slogx.Logger, level slog.Leveler) *log.Logger {
+	return log.New(NewWriter(logger, &Options{Level: level}), "", 0)
+}
+
+// Redirect redirects the output of the standard logger, see [log.Default], to the logger.
+// It keeps the flags and the prefix of the standard logger, so that the headers it adds can be parsed and dropped.
+// The returned function restores the previous output.
+func Redirect(logger *slogx.Logger, level slog.Leveler) (restore func()) {
+	std := log.Default()
+	output := std.Writer()
+
+	std.SetOutput(NewWriter(logger, &Options{
+		Level:  level,
+		Flags:  std.Flags(),
+		Prefix: std.Prefix(),
+	}))
+
+	return func() {
+		std.SetOutput(output)
+	}
+}
+
+// ---
+
+// Options contains options for the [Writer].
+type Options struct {
+	// Level is the level of the log records, [slog.LevelInfo] is used if it is nil.
+	Level slog.Leveler
+	// Flags are the flags of the [log.Logger] writing to the [Writer], see [log.Flags].
+	// They are used to parse and drop the header of the lines.
+	Flags int
+	// Prefix is the prefix of the [log.Logger] writing to the [Writer], see [log.Prefix].
+	// It is dropped from the lines.
+	Prefix string
+}
+
+// ---
+
+// Writer is an [io.Writer] that parses lines written by a [log.Logger] and sends them as log records to a [slogx.Logger].
+// The date, time and file headers added by the [log.Logger] are dropped
+// because the log records have their own time and source location.
+// The source location is determined by skipping the frames of the [log] package.
+type Writer struct {
+	logger  *slogx.Logger
+	options Options
+}
+
+// Write parses the line and sends it as a log record.
+func (w *Writer) Write(p []byte) (int, error) {
+	level := slog.LevelInfo
+	if w.options.Level != nil {
+		level = w.options.Level.Level()
+	}
+
+	ctx := context.Background()
+	if !w.logger.Enabled(ctx, level) {
+		return len(p), nil
+	}
+
+	msg := w.parse(string(bytes.TrimSuffix(p, []byte("\n"))))
+	w.logger.LogWithPC(ctx, callerPC(), level, msg)
+
+	return len(p), nil
+}
+
+// ---
+
+func (w *Writer) parse(line string) string {
+	flags := w.options.Flags
+	prefix := w.options.Prefix
+
+	if flags&log.Lmsgprefix == 0 {
+		line = strings.TrimPrefix(line, prefix)
+	}
+
+	if flags&log.Ldate != 0 {
+		line = skipField(line)
+	}
+
+	if flags&(log.Ltime|log.Lmicroseconds) != 0 {
+		line = skipField(line)
+	}
+
+	if flags&(log.Lshortfile|log.Llongfile) != 0 {
+		if i := strings.Index(line, ": "); i >= 0 {
+			line = line[i+2:]
+		}
+	}
+
+	if flags&log.Lmsgprefix != 0 {
+		line = strings.TrimPrefix(line, prefix)
+	}
+
+	return line
+}
+
+func skipField(line string) string {
+	if i := strings.IndexByte(line, ' '); i >= 0 {
+		return line[i+1:]
+	}
+
+	return line
+}
+
+func callerPC() uintptr {
+	var pcs [16]uintptr
+
+	n := runtime.Callers(3, pcs[:])
+	for _, pc := range pcs[:n] {
+		fn := runtime.FuncForPC(pc - 1)
+		if fn == nil || !strings.HasPrefix(fn.Name(), "log.") {
+			return pc
+		}
+	}
+
+	return 0
+}
@@ -0,0 +1,97 @@
+package slogxlog_test
+
+import (
+	"log"
+	"log/slog"
+	"runtime"
+	"testing"
+
+	. "github.com/pamburus/go-tst/tst"
+	"github.com/pamburus/slogx"
+	"github.com/pamburus/slogx/internal/mock"
+	"github.com/pamburus/slogx/slogxlog"
+)
+
+func TestWriter(tt *testing.T) {
+	t := New(tt)
+
+	setup := func() (*mock.CallLog, *slogx.Logger) {
+		cl := mock.NewCallLog()
+
+		return cl, slogx.New(mock.NewHandler(cl))
+	}
+
+	records := func(cl *mock.CallLog) []mock.Record {
+		var result []mock.Record
+
+		for _, call := range cl.Calls().WithoutTime() {
+			if call, ok := call.(mock.HandlerHandle); ok {
+				result = append(result, call.Record)
+			}
+		}
+
+		return result
+	}
+
+	t.Run("Headers", func(t Test) {
+		for _, tc := range []struct {
+			flags int
+			line  string
+		}{
+			{0, "app: m1\n"},
+			{log.LstdFlags, "app: 2024/01/02 03:04:05 m1\n"},
+			{log.LstdFlags | log.Lmicroseconds | log.Lshortfile, "app: 2024/01/02 03:04:05.123456 file.go:12: m1\n"},
+			{log.Ltime | log.Llongfile | log.Lmsgprefix, "03:04:05 /src/file.go:12: app: m1"},
+		} {
+			cl, logger := setup()
+			w := slogxlog.NewWriter(logger.WithSource(false), &slogxlog.Options{
+				Level:  slog.LevelWarn,
+				Flags:  tc.flags,
+				Prefix: "app: ",
+			})
+
+			t.Expect(w.Write([]byte(tc.line))).ToSucceed().AndResult().To(Equal(len(tc.line)))
+			t.Expect(records(cl)).To(Equal([]mock.Record{{Level: slog.LevelWarn, Message: "m1"}}))
+		}
+	})
+
+	t.Run("Logger", func(t Test) {
+		cl, logger := setup()
+
+		slogxlog.NewLogger(logger, nil).Printf("m%d", 1)
+
+		result := records(cl)
+		t.Expect(result).To(HaveLen(1))
+		t.Expect(result[0].Level).To(Equal(slog.LevelInfo))
+		t.Expect(result[0].Message).To(Equal("m1"))
+		t.Expect(functionOf(result[0].PC)).To(Equal("github.com/pamburus/slogx/slogxlog_test.TestWriter.func4"))
+	})
+
+	t.Run("Redirect", func(t Test) {
+		cl, logger := setup()
+
+		restore := slogxlog.Redirect(logger, slog.LevelDebug)
+		log.Print("m1")
+		restore()
+
+		result := records(cl)
+		t.Expect(result).To(HaveLen(1))
+		t.Expect(result[0].Level).To(Equal(slog.LevelDebug))
+		t.Expect(result[0].Message).To(Equal("m1"))
+		t.Expect(functionOf(result[0].PC)).To(Equal("github.com/pamburus/slogx/slogxlog_test.TestWriter.func5"))
+	})
+
+	t.Run("Disabled", func(t Test) {
+		cl := mock.NewCallLog()
+		handler := slogx.TweakHandler(mock.NewHandler(cl)).WithLevel(slog.LevelInfo).Result()
+
+		slogxlog.NewLogger(slogx.New(handler), slog.LevelDebug).Print("m1")
+		t.Expect(records(cl)).To(HaveLen(0))
+	})
+}
+
+func functionOf(pc uintptr) string {
+	frame, _ := runtime.CallersFrames([]uintptr{pc}).Next()
+
+	return frame.Function
+}
@@ -0,0 +1,11 @@
+module github.com/pamburus/slogx/slogxlogr
+
+go 1.22
+
+replace github.com/pamburus/slogx => ../
+
+require (
+	github.com/go-logr/logr v1.4.2
+	github.com/pamburus/go-tst v0.6.0
+	github.com/pamburus/slogx v0.0.0
+)
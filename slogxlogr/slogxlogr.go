@@ -0,0 +1,140 @@
+// Package slogxlogr provides a [logr.LogSink] implementation backed by [slogx.ContextLogger].
+package slogxlogr
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/go-logr/logr"
+	"github.com/pamburus/slogx"
+	"github.com/pamburus/slogx/slogc"
+)
+
+// New returns a new [logr.Logger] that sends log records to the logger.
+// See [NewLogSink] for details.
+func New(logger *slogx.Logger) logr.Logger {
+	return logr.New(NewLogSink(logger.ContextLogger()))
+}
+
+// NewLogSink returns a new [logr.LogSink] that sends log records to the logger.
+//
+// Verbosity levels are mapped to [slog.Level] by negation, so V(0) corresponds to [slog.LevelInfo]
+// and V(4) corresponds to [slog.LevelDebug].
+// Errors are logged at [slog.LevelError] with the error in [slogx.ErrorKey] attribute.
+// Names added by [logr.Logger.WithName] are stored in the context using [slogc.WithName],
+// so they can be used by [slogc.NameAttr] and [slogc.NameLevel].
+// Values added by [logr.Logger.WithValues] are added using [slogx.ContextLogger.With],
+// so they are buffered in the logger instead of being applied to the handler immediately.
+func NewLogSink(logger *slogx.ContextLogger) logr.LogSink {
+	return &logSink{
+		logger: logger,
+		ctx:    context.Background(),
+	}
+}
+
+// ---
+
+type logSink struct {
+	logger *slogx.ContextLogger
+	ctx    context.Context
+	depth  int
+}
+
+func (s *logSink) Init(info logr.RuntimeInfo) {
+	s.depth = info.CallDepth
+}
+
+func (s *logSink) Enabled(level int) bool {
+	return s.logger.Enabled(s.ctx, verbosityLevel(level))
+}
+
+func (s *logSink) Info(level int, msg string, keysAndValues ...any) {
+	s.logger.LogWithCallerSkip(s.ctx, s.depth+1, verbosityLevel(level), msg, attrs(keysAndValues)...)
+}
+
+func (s *logSink) Error(err error, msg string, keysAndValues ...any) {
+	attrs := append(make([]slog.Attr, 0, 1+len(keysAndValues)/2), slogx.ErrorAttr(err))
+	attrs = appendAttrs(attrs, keysAndValues)
+
+	s.logger.LogWithCallerSkip(s.ctx, s.depth+1, slog.LevelError, msg, attrs...)
+}
+
+func (s *logSink) WithValues(keysAndValues ...any) logr.LogSink {
+	if len(keysAndValues) == 0 {
+		return s
+	}
+
+	s = s.clone()
+	s.logger = s.logger.With(attrs(keysAndValues)...)
+
+	return s
+}
+
+func (s *logSink) WithName(name string) logr.LogSink {
+	s = s.clone()
+	s.ctx = slogc.WithName(s.ctx, name)
+
+	return s
+}
+
+func (s *logSink) WithCallDepth(depth int) logr.LogSink {
+	s = s.clone()
+	s.depth += depth
+
+	return s
+}
+
+func (s logSink) clone() *logSink {
+	return &s
+}
+
+// ---
+
+func verbosityLevel(level int) slog.Level {
+	return slog.Level(-level)
+}
+
+func attrs(keysAndValues []any) []slog.Attr {
+	if len(keysAndValues) == 0 {
+		return nil
+	}
+
+	return appendAttrs(make([]slog.Attr, 0, (len(keysAndValues)+1)/2), keysAndValues)
+}
+
+func appendAttrs(attrs []slog.Attr, keysAndValues []any) []slog.Attr {
+	for i := 0; i < len(keysAndValues); i += 2 {
+		if i+1 == len(keysAndValues) {
+			attrs = append(attrs, slog.Any(badKey, value(keysAndValues[i])))
+
+			break
+		}
+
+		key, ok := keysAndValues[i].(string)
+		if !ok {
+			key = fmt.Sprint(keysAndValues[i])
+		}
+
+		attrs = append(attrs, slog.Any(key, value(keysAndValues[i+1])))
+	}
+
+	return attrs
+}
+
+func value(v any) any {
+	if m, ok := v.(logr.Marshaler); ok {
+		return m.MarshalLog()
+	}
+
+	return v
+}
+
+const badKey = "!BADKEY"
+
+// ---
+
+var (
+	_ logr.LogSink          = (*logSink)(nil)
+	_ logr.CallDepthLogSink = (*logSink)(nil)
+)
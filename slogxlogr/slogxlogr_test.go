@@ -0,0 +1,110 @@
+package slogxlogr_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr"
+	. "github.com/pamburus/go-tst/tst"
+	"github.com/pamburus/slogx"
+	"github.com/pamburus/slogx/internal/mock"
+	"github.com/pamburus/slogx/slogc"
+	"github.com/pamburus/slogx/slogxlogr"
+)
+
+func TestLogSink(tt *testing.T) {
+	t := New(tt)
+
+	setup := func(t Test) (func() []map[string]any, logr.Logger) {
+		var buf bytes.Buffer
+
+		handler := slogx.TweakHandler(slog.NewJSONHandler(&buf, &slog.HandlerOptions{
+			AddSource: true,
+			Level:     slog.LevelDebug,
+			ReplaceAttr: func(groups []string, attr slog.Attr) slog.Attr {
+				if len(groups) == 0 && attr.Key == slog.TimeKey {
+					return slog.Attr{}
+				}
+
+				return attr
+			},
+		})).WithDynamicAttr(slogc.NameAttr("logger")).Result()
+
+		logger := logr.New(slogxlogr.NewLogSink(slogx.NewContextLogger(handler)))
+
+		records := func() []map[string]any {
+			var result []map[string]any
+
+			for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+				var record map[string]any
+				t.Expect(json.Unmarshal([]byte(line), &record)).ToNot(HaveOccurred())
+
+				source := record[slog.SourceKey].(map[string]any)
+				t.Expect(source["function"]).To(Equal("github.com/pamburus/slogx/slogxlogr_test.TestLogSink.func2"))
+				delete(record, slog.SourceKey)
+
+				result = append(result, record)
+			}
+
+			return result
+		}
+
+		return records, logger
+	}
+
+	t.Run("Info", func(t Test) {
+		records, logger := setup(t)
+
+		logger.Info("m1", "a", 1, "b")
+		logger.V(4).Info("m2", 42, "x")
+		logger.V(5).Info("m3")
+		logger.WithValues("c", marshaler{}).WithName("n1").WithName("n2").Info("m4")
+		logger.WithCallDepth(0).Error(errors.New("e1"), "m5", "d", true)
+
+		t.Expect(records()).To(Equal([]map[string]any{
+			{"level": "INFO", "msg": "m1", "a": float64(1), "!BADKEY": "b"},
+			{"level": "DEBUG", "msg": "m2", "42": "x"},
+			{"level": "INFO", "msg": "m4", "c": "marshaled", "logger": "n1.n2"},
+			{"level": "ERROR", "msg": "m5", "error": "e1", "d": true},
+		}))
+	})
+
+	t.Run("Enabled", func(t Test) {
+		_, logger := setup(t)
+
+		t.Expect(logger.Enabled()).To(BeTrue())
+		t.Expect(logger.V(4).Enabled()).To(BeTrue())
+		t.Expect(logger.V(5).Enabled()).To(BeFalse())
+	})
+}
+
+// ---
+
+type marshaler struct{}
+
+func (marshaler) MarshalLog() any {
+	return "marshaled"
+}
+
+func TestNew(tt *testing.T) {
+	t := New(tt)
+
+	cl := mock.NewCallLog()
+	logger := slogxlogr.New(slogx.New(mock.NewHandler(cl)).WithSource(false))
+
+	logger.WithValues("a", 1).V(1).Info("m1", "b", 2)
+
+	t.Expect(cl.Calls().WithoutTime()...).To(Equal(
+		mock.HandlerEnabled{Instance: "0", Level: -1},
+		mock.HandlerEnabled{Instance: "0", Level: -1},
+		mock.HandlerHandle{Instance: "0", Record: mock.Record{
+			Level:   -1,
+			Message: "m1",
+			Attrs:   []mock.Attr{{Key: "a", Value: int64(1)}, {Key: "b", Value: int64(2)}},
+		}},
+	))
+}
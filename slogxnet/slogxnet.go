@@ -0,0 +1,642 @@
+// Package slogxnet provides a [slog.Handler] streaming log records to a remote collector
+// over TCP, TLS or unix sockets with automatic reconnection and spooling.
+package slogxnet
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/pamburus/slogx"
+)
+
+// NewHandler returns a new [slog.Handler] encoding log records as JSON and streaming them
+// to the collector at the address on the named network, such as "tcp" or "unix".
+//
+// The connection is established in the background and re-established with exponential backoff when it breaks.
+// Records are queued and sent over the connection by a background goroutine, so logging does not wait for the network.
+// If sending fails, the records that were not sent completely are moved to the spool along with the queued ones.
+// While the connection is down, encoded records are appended to the spool, see [HandlerOptions.SpoolPath],
+// and they are replayed in order once the connection is up again, before any new records.
+// The spool is replayed in chunks of whole records without blocking new records, which are appended to the spool meanwhile.
+// Delivery is at-least-once: the chunk that was being replayed when the connection broke is replayed again.
+//
+// The handler implements [slogx.Flusher] and [slogx.Closer].
+// Flushing waits until the queued records are sent and the spool is replayed or the context is done.
+// If the connection is down and the reconnection attempt made by flushing fails,
+// flushing returns an error wrapping [ErrUnavailable] and the records stay in the spool,
+// so flushing does not block forever, for example in [slogx.Exit], while the collector is down.
+// Closing makes a last attempt to replay the spool and keeps the records that could not be delivered
+// in the on-disk spool for the next run.
+// See [HandlerOptions] for the available options, nil options mean default options.
+func NewHandler(network, address string, options *HandlerOptions) (slog.Handler, error) {
+	if options == nil {
+		options = &HandlerOptions{}
+	}
+
+	w, err := NewWriter(network, address, options)
+	if err != nil {
+		return nil, err
+	}
+
+	return &handler{slog.NewJSONHandler(w, options.JSON), w.sink}, nil
+}
+
+// NewWriter returns a new [Writer] streaming encoded records to the collector at the address on the named network.
+// It allows using the connection management and spooling of [NewHandler] with handlers encoding records in other formats.
+// See [NewHandler] for details, the JSON options are ignored.
+func NewWriter(network, address string, options *HandlerOptions) (*Writer, error) {
+	if options == nil {
+		options = &HandlerOptions{}
+	}
+
+	s, err := newSink(network, address, *options)
+	if err != nil {
+		return nil, err
+	}
+
+	go s.run()
+
+	return &Writer{s}, nil
+}
+
+// ---
+
+// Writer is an [io.Writer] streaming encoded records to a remote collector.
+// Each call to Write must contain exactly one encoded record, as [slog.JSONHandler] and [slog.TextHandler] do.
+type Writer struct {
+	sink *sink
+}
+
+// Write queues the record for sending or appends it to the spool if the connection is down.
+func (w *Writer) Write(record []byte) (int, error) {
+	return w.sink.Write(record)
+}
+
+// Flush waits until the queued records are sent and the spool is replayed or the context is done.
+// If the connection is down and the reconnection attempt fails, it returns an error wrapping [ErrUnavailable].
+func (w *Writer) Flush(ctx context.Context) error {
+	return w.sink.flush(ctx)
+}
+
+// Close makes a last attempt to replay the spool and closes the connection.
+func (w *Writer) Close() error {
+	return w.sink.close()
+}
+
+// ---
+
+// HandlerOptions contains options for [NewHandler].
+type HandlerOptions struct {
+	// JSON contains options of the JSON encoding, see [slog.NewJSONHandler].
+	JSON *slog.HandlerOptions
+	// Framing specifies how records are delimited in the stream, the default is [FramingLines].
+	Framing Framing
+	// TLS, if not nil, enables TLS with the given configuration.
+	TLS *tls.Config
+	// DialTimeout is the timeout of connection attempts, the default is 5 seconds.
+	DialTimeout time.Duration
+	// WriteTimeout is the timeout of writing a record or a chunk of the spool being replayed, the default is 5 seconds.
+	WriteTimeout time.Duration
+	// MinBackoff is the delay before the first reconnection attempt, the default is 100 milliseconds.
+	// The delay doubles after each failed attempt up to MaxBackoff.
+	MinBackoff time.Duration
+	// MaxBackoff is the maximum delay between reconnection attempts, the default is 30 seconds.
+	MaxBackoff time.Duration
+	// SpoolPath is the path of the file keeping records while the connection is down.
+	// Records left in the file by a previous run are replayed as well.
+	// If it is empty, records are kept in memory.
+	SpoolPath string
+	// MaxSpoolSize is the maximum size of the spool in bytes, including the records queued for sending,
+	// the default is 64 MiB.
+	// Records that do not fit into the spool are dropped and [ErrSpoolFull] is returned.
+	MaxSpoolSize int64
+}
+
+// Framing specifies how records are delimited in the stream.
+type Framing int
+
+// Supported framings.
+const (
+	// FramingLines sends each record as a line of JSON terminated by a newline.
+	FramingLines Framing = iota
+	// FramingLengthPrefix sends each record as JSON without a trailing newline
+	// prefixed by its length as a big-endian 32-bit unsigned integer.
+	FramingLengthPrefix
+)
+
+// ---
+
+// Errors returned by the handler.
+var (
+	ErrSpoolFull   = errors.New("slogxnet: spool is full")
+	ErrClosed      = errors.New("slogxnet: handler is closed")
+	ErrUnavailable = errors.New("slogxnet: collector is unavailable")
+)
+
+// ---
+
+type handler struct {
+	slog.Handler
+	sink *sink
+}
+
+func (h *handler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+
+	return &handler{h.Handler.WithAttrs(attrs), h.sink}
+}
+
+func (h *handler) WithGroup(key string) slog.Handler {
+	if key == "" {
+		return h
+	}
+
+	return &handler{h.Handler.WithGroup(key), h.sink}
+}
+
+func (h *handler) Flush(ctx context.Context) error {
+	return h.sink.flush(ctx)
+}
+
+func (h *handler) Close(context.Context) error {
+	return h.sink.close()
+}
+
+// ---
+
+const (
+	defaultDialTimeout  = 5 * time.Second
+	defaultWriteTimeout = 5 * time.Second
+	defaultMinBackoff   = 100 * time.Millisecond
+	defaultMaxBackoff   = 30 * time.Second
+	defaultMaxSpoolSize = 64 << 20
+	flushPollInterval   = 10 * time.Millisecond
+	replayChunkSize     = 64 << 10
+)
+
+// ---
+
+// sink receives encoded records from the JSON handler, one record per Write call,
+// and either queues them for sending over the connection or appends them to the spool.
+type sink struct {
+	network string
+	address string
+	options HandlerOptions
+
+	mu       sync.Mutex
+	conn     net.Conn
+	queue    []byte
+	spare    []byte
+	sending  bool
+	spool    spool
+	replayed int64
+	closed   bool
+	failures int
+	err      error
+
+	wake     chan struct{}
+	stop     chan struct{}
+	stopOnce sync.Once
+	done     chan struct{}
+}
+
+func newSink(network, address string, options HandlerOptions) (*sink, error) {
+	setDefault(&options.DialTimeout, defaultDialTimeout)
+	setDefault(&options.WriteTimeout, defaultWriteTimeout)
+	setDefault(&options.MinBackoff, defaultMinBackoff)
+	setDefault(&options.MaxBackoff, defaultMaxBackoff)
+	setDefault(&options.MaxSpoolSize, defaultMaxSpoolSize)
+
+	var sp spool = &memorySpool{}
+	if options.SpoolPath != "" {
+		fsp, err := openFileSpool(options.SpoolPath, options.Framing)
+		if err != nil {
+			return nil, err
+		}
+
+		sp = fsp
+	}
+
+	return &sink{
+		network: network,
+		address: address,
+		options: options,
+		spool:   sp,
+		wake:    make(chan struct{}, 1),
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}, nil
+}
+
+func (s *sink) Write(record []byte) (int, error) {
+	frame := s.frame(record)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return 0, ErrClosed
+	}
+
+	if s.spool.Size()+int64(len(s.queue)+len(frame)) > s.options.MaxSpoolSize {
+		return 0, ErrSpoolFull
+	}
+
+	if s.conn != nil {
+		s.queue = append(s.queue, frame...)
+		s.wakeUp()
+
+		return len(record), nil
+	}
+
+	err := s.spool.Append(frame)
+	if err != nil {
+		return 0, err
+	}
+
+	return len(record), nil
+}
+
+func (s *sink) frame(record []byte) []byte {
+	if s.options.Framing != FramingLengthPrefix {
+		return record
+	}
+
+	if len(record) != 0 && record[len(record)-1] == '\n' {
+		record = record[:len(record)-1]
+	}
+
+	frame := make([]byte, 0, 4+len(record))
+	frame = binary.BigEndian.AppendUint32(frame, uint32(len(record)))
+
+	return append(frame, record...)
+}
+
+// drain sends the queued records over the connection without holding the mutex during the network writes.
+// The records are sent in chunks, each with its own write deadline.
+// If sending fails, the records that were not sent completely are appended to the spool,
+// followed by the records queued meanwhile, and the reconnection loop is woken up.
+func (s *sink) drain() error {
+	for {
+		s.mu.Lock()
+
+		conn, batch := s.conn, s.queue
+		if conn == nil || len(batch) == 0 {
+			s.mu.Unlock()
+
+			return nil
+		}
+
+		s.queue, s.spare = s.spare[:0], nil
+		s.sending = true
+		s.mu.Unlock()
+
+		sent := 0
+
+		var err error
+
+		for sent != len(batch) && err == nil {
+			n := min(len(batch)-sent, replayChunkSize)
+
+			err = s.send(conn, batch[sent:sent+n])
+			if err == nil {
+				sent += n
+			}
+		}
+
+		s.mu.Lock()
+		s.sending = false
+
+		if err != nil {
+			s.disconnect()
+
+			err = errors.Join(err,
+				s.spool.Append(batch[s.options.Framing.complete(batch[:sent]):]),
+				s.spool.Append(s.queue),
+			)
+			s.queue = s.queue[:0]
+			s.mu.Unlock()
+
+			return err
+		}
+
+		s.spare = batch[:0]
+		s.mu.Unlock()
+	}
+}
+
+func (s *sink) send(conn net.Conn, data []byte) error {
+	err := conn.SetWriteDeadline(time.Now().Add(s.options.WriteTimeout))
+	if err != nil {
+		return err
+	}
+
+	_, err = conn.Write(data)
+
+	return err
+}
+
+// disconnect closes the broken connection and wakes up the reconnection loop.
+// It must be called with the mutex locked.
+func (s *sink) disconnect() {
+	_ = s.conn.Close()
+	s.conn = nil
+	s.wakeUp()
+}
+
+func (s *sink) wakeUp() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+// run maintains the connection and sends the queued records until the sink is closed.
+func (s *sink) run() {
+	defer close(s.done)
+
+	s.wakeUp()
+
+	for {
+		select {
+		case <-s.stop:
+			return
+		case <-s.wake:
+		}
+
+		_ = s.drain()
+
+		backoff := s.options.MinBackoff
+
+		for {
+			err := s.connect()
+			if err == nil {
+				break
+			}
+
+			s.fail(err)
+
+			timer := time.NewTimer(backoff)
+
+			select {
+			case <-s.stop:
+				timer.Stop()
+
+				return
+			case <-timer.C:
+			case <-s.wake:
+				timer.Stop()
+			}
+
+			backoff = min(backoff*2, s.options.MaxBackoff)
+		}
+	}
+}
+
+// connect establishes the connection, if it is not established yet, and replays the spool.
+// The spool is replayed chunk by chunk without holding the mutex during the network writes,
+// and records written meanwhile are appended to the spool, so they are replayed after the ones already there.
+// The connection is used for new records only after the spool is replayed completely.
+func (s *sink) connect() error {
+	s.mu.Lock()
+	connected := s.conn != nil
+	s.mu.Unlock()
+
+	if connected {
+		return nil
+	}
+
+	conn, err := s.dial()
+	if err != nil {
+		return err
+	}
+
+	var buf []byte
+
+	for {
+		var chunk []byte
+
+		chunk, buf, err = s.nextChunk(conn, buf)
+		if err != nil || chunk == nil {
+			return err
+		}
+
+		err = s.send(conn, chunk)
+		if err != nil {
+			_ = conn.Close()
+
+			return err
+		}
+
+		s.mu.Lock()
+		s.replayed += int64(len(chunk))
+		s.mu.Unlock()
+	}
+}
+
+// nextChunk returns the next chunk of the spool to replay, consisting of whole records.
+// If the spool is replayed completely, it resets the spool, starts using the connection and returns a nil chunk.
+func (s *sink) nextChunk(conn net.Conn, buf []byte) ([]byte, []byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed || s.conn != nil {
+		return nil, buf, conn.Close()
+	}
+
+	remaining := s.spool.Size() - s.replayed
+	if remaining == 0 {
+		err := s.spool.Reset()
+		if err != nil {
+			_ = conn.Close()
+
+			return nil, buf, err
+		}
+
+		s.replayed = 0
+		s.conn = conn
+
+		return nil, buf, nil
+	}
+
+	size := min(remaining, replayChunkSize)
+
+	for {
+		if int64(cap(buf)) < size {
+			buf = make([]byte, size)
+		}
+
+		chunk := buf[:size]
+
+		_, err := s.spool.ReadAt(chunk, s.replayed)
+		if err != nil {
+			_ = conn.Close()
+
+			return nil, buf, err
+		}
+
+		// A chunk is extended until it contains at least one whole record.
+		n := s.options.Framing.complete(chunk)
+		switch {
+		case n != 0:
+			return chunk[:n], buf, nil
+		case size == remaining:
+			return chunk, buf, nil
+		}
+
+		size = min(size*2, remaining)
+	}
+}
+
+// fail records the failed connection attempt, so waiting flushes can give up.
+func (s *sink) fail(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.failures++
+	s.err = err
+}
+
+func (s *sink) dial() (net.Conn, error) {
+	dialer := &net.Dialer{Timeout: s.options.DialTimeout}
+
+	if s.options.TLS != nil {
+		return tls.DialWithDialer(dialer, s.network, s.address, s.options.TLS)
+	}
+
+	return dialer.Dial(s.network, s.address)
+}
+
+// flush waits until the connection is established, the spool is replayed and the queued records are sent.
+// It wakes up the reconnection loop to skip the current backoff delay,
+// and gives up if the connection is still down after a connection attempt fails.
+func (s *sink) flush(ctx context.Context) error {
+	s.mu.Lock()
+	failures := s.failures
+	s.mu.Unlock()
+
+	s.wakeUp()
+
+	ticker := time.NewTicker(flushPollInterval)
+	defer ticker.Stop()
+
+	for {
+		s.mu.Lock()
+		closed, drained := s.closed, s.conn != nil && s.spool.Size() == 0 && len(s.queue) == 0 && !s.sending
+		down, err := s.conn == nil && s.failures != failures, s.err
+		s.mu.Unlock()
+
+		switch {
+		case closed:
+			return ErrClosed
+		case drained:
+			return nil
+		case down:
+			return fmt.Errorf("%w: %w", ErrUnavailable, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// close stops the reconnection loop, makes a last attempt to replay the spool and releases the resources.
+func (s *sink) close() error {
+	s.stopOnce.Do(func() {
+		close(s.stop)
+	})
+	<-s.done
+
+	s.mu.Lock()
+	closed := s.closed
+	s.mu.Unlock()
+
+	if closed {
+		return nil
+	}
+
+	errs := []error{s.drain()}
+
+	s.mu.Lock()
+	pending := s.spool.Size() != 0
+	s.mu.Unlock()
+
+	if pending {
+		errs = append(errs, s.connect(), s.drain())
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return errors.Join(errs...)
+	}
+
+	s.closed = true
+
+	// Records queued after the last attempt are kept in the spool.
+	if len(s.queue) != 0 {
+		errs = append(errs, s.spool.Append(s.queue))
+		s.queue = nil
+	}
+
+	if s.conn != nil {
+		errs = append(errs, s.conn.Close())
+		s.conn = nil
+	}
+
+	errs = append(errs, s.spool.Close())
+
+	return errors.Join(errs...)
+}
+
+// ---
+
+// complete returns the length of the longest prefix of the data consisting of whole records.
+func (f Framing) complete(data []byte) int {
+	if f != FramingLengthPrefix {
+		return bytes.LastIndexByte(data, '\n') + 1
+	}
+
+	n := 0
+
+	for len(data)-n >= 4 {
+		size := 4 + int(binary.BigEndian.Uint32(data[n:]))
+		if len(data)-n < size {
+			break
+		}
+
+		n += size
+	}
+
+	return n
+}
+
+// ---
+
+func setDefault[T comparable](value *T, defaultValue T) {
+	var zero T
+	if *value == zero {
+		*value = defaultValue
+	}
+}
+
+// ---
+
+var (
+	_ slogx.Flusher = (*handler)(nil)
+	_ slogx.Closer  = (*handler)(nil)
+)
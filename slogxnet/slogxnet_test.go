@@ -0,0 +1,421 @@
+package slogxnet_test
+
+import (
+	"bufio"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	. "github.com/pamburus/go-tst/tst"
+	"github.com/pamburus/slogx"
+	"github.com/pamburus/slogx/slogxnet"
+)
+
+func TestHandler(tt *testing.T) {
+	t := New(tt)
+
+	timeout := func() (context.Context, context.CancelFunc) {
+		return context.WithTimeout(context.Background(), 5*time.Second)
+	}
+
+	options := func(spool string) *slogxnet.HandlerOptions {
+		return &slogxnet.HandlerOptions{
+			JSON:       &slog.HandlerOptions{ReplaceAttr: dropTime},
+			MinBackoff: time.Millisecond,
+			MaxBackoff: 10 * time.Millisecond,
+			SpoolPath:  spool,
+		}
+	}
+
+	t.Run("UpAndDown", func(t Test) {
+		dir := tt.TempDir()
+		path := filepath.Join(dir, "collector.sock")
+		spool := filepath.Join(dir, "spool")
+
+		handler, err := slogxnet.NewHandler("unix", path, options(spool))
+		t.Expect(err).ToNot(HaveOccurred())
+
+		logger := slogx.New(handler).WithSource(false)
+
+		// The collector is down, the record goes to the spool.
+		logger.Info("m1")
+		t.Expect(fileSize(t, spool)).To(BeGreaterThan(int64(0)))
+
+		c := startCollector(t, "unix", path, nil)
+		ctx, cancel := timeout()
+		defer cancel()
+
+		t.Expect(slogx.Flush(ctx, handler)).ToNot(HaveOccurred())
+		t.Expect(fileSize(t, spool)).To(Equal(int64(0)))
+
+		logger.Info("m2", slog.Int("a", 1))
+		t.Expect(c.readLine(t)).To(Equal(`{"level":"INFO","msg":"m1"}`))
+		t.Expect(c.readLine(t)).To(Equal(`{"level":"INFO","msg":"m2","a":1}`))
+
+		// The collector goes down, sending to the broken connection fails and the records go to the spool.
+		// Flushing gives up after the reconnection attempt fails.
+		c.stop(t)
+		logger.Info("m3")
+		logger.Warn("m4")
+		t.Expect(errors.Is(slogx.Flush(ctx, handler), slogxnet.ErrUnavailable)).To(BeTrue())
+		t.Expect(fileSize(t, spool)).To(BeGreaterThan(int64(0)))
+
+		c = startCollector(t, "unix", path, nil)
+		defer c.stop(t)
+
+		t.Expect(slogx.Flush(ctx, handler)).ToNot(HaveOccurred())
+		logger.Info("m5")
+		t.Expect(c.readLine(t)).To(Equal(`{"level":"INFO","msg":"m3"}`))
+		t.Expect(c.readLine(t)).To(Equal(`{"level":"WARN","msg":"m4"}`))
+		t.Expect(c.readLine(t)).To(Equal(`{"level":"INFO","msg":"m5"}`))
+
+		t.Expect(slogx.Close(ctx, handler)).ToNot(HaveOccurred())
+		t.Expect(slogx.Close(ctx, handler)).ToNot(HaveOccurred())
+		t.Expect(handler.Handle(ctx, slog.NewRecord(time.Now(), slog.LevelInfo, "m6", 0))).To(MatchError(slogxnet.ErrClosed))
+		t.Expect(slogx.Flush(ctx, handler)).To(MatchError(slogxnet.ErrClosed))
+	})
+
+	t.Run("Persistence", func(t Test) {
+		dir := tt.TempDir()
+		path := filepath.Join(dir, "collector.sock")
+		spool := filepath.Join(dir, "spool")
+
+		ctx, cancel := timeout()
+		defer cancel()
+
+		handler, err := slogxnet.NewHandler("unix", path, options(spool))
+		t.Expect(err).ToNot(HaveOccurred())
+
+		slogx.New(handler).WithSource(false).WithGroup("g").Info("m1", slog.Int("a", 1))
+		t.Expect(slogx.Close(ctx, handler)).To(HaveOccurred())
+		t.Expect(fileSize(t, spool)).To(BeGreaterThan(int64(0)))
+
+		c := startCollector(t, "unix", path, nil)
+		defer c.stop(t)
+
+		handler, err = slogxnet.NewHandler("unix", path, options(spool))
+		t.Expect(err).ToNot(HaveOccurred())
+		t.Expect(slogx.Flush(ctx, handler)).ToNot(HaveOccurred())
+		t.Expect(c.readLine(t)).To(Equal(`{"level":"INFO","msg":"m1","g":{"a":1}}`))
+		t.Expect(slogx.Close(ctx, handler)).ToNot(HaveOccurred())
+	})
+
+	t.Run("LargeSpool", func(t Test) {
+		path := filepath.Join(tt.TempDir(), "collector.sock")
+
+		handler, err := slogxnet.NewHandler("unix", path, options(""))
+		t.Expect(err).ToNot(HaveOccurred())
+
+		logger := slogx.New(handler).WithSource(false)
+
+		const n = 2000
+		padding := strings.Repeat("x", 100)
+
+		for i := range n {
+			logger.Info("m1", slog.Int("i", i), slog.String("p", padding))
+		}
+
+		c := startCollector(t, "unix", path, nil)
+		defer c.stop(t)
+
+		for i := range n {
+			t.Expect(c.readLine(t)).To(Equal(fmt.Sprintf(`{"level":"INFO","msg":"m1","i":%d,"p":"%s"}`, i, padding)))
+		}
+
+		ctx, cancel := timeout()
+		defer cancel()
+
+		t.Expect(slogx.Flush(ctx, handler)).ToNot(HaveOccurred())
+		logger.Info("m2")
+		t.Expect(c.readLine(t)).To(Equal(`{"level":"INFO","msg":"m2"}`))
+		t.Expect(slogx.Close(ctx, handler)).ToNot(HaveOccurred())
+	})
+
+	t.Run("SlowCollector", func(t Test) {
+		c := startCollector(t, "unix", filepath.Join(tt.TempDir(), "collector.sock"), nil)
+		defer c.stop(t)
+
+		handler, err := slogxnet.NewHandler("unix", c.listener.Addr().String(), options(""))
+		t.Expect(err).ToNot(HaveOccurred())
+
+		ctx, cancel := timeout()
+		defer cancel()
+
+		t.Expect(slogx.Flush(ctx, handler)).ToNot(HaveOccurred())
+
+		logger := slogx.New(handler).WithSource(false)
+
+		// The collector does not read until all records are logged, so sending blocks,
+		// but logging does not wait for it.
+		const n = 20000
+		padding := strings.Repeat("x", 100)
+		start := time.Now()
+
+		for i := range n {
+			logger.Info("m1", slog.Int("i", i), slog.String("p", padding))
+		}
+
+		t.Expect(time.Since(start)).To(BeLessOrEqualThan(time.Second))
+
+		for i := range n {
+			t.Expect(c.readLine(t)).To(Equal(fmt.Sprintf(`{"level":"INFO","msg":"m1","i":%d,"p":"%s"}`, i, padding)))
+		}
+
+		t.Expect(slogx.Close(ctx, handler)).ToNot(HaveOccurred())
+	})
+
+	t.Run("TornSpool", func(t Test) {
+		for _, framing := range []slogxnet.Framing{slogxnet.FramingLines, slogxnet.FramingLengthPrefix} {
+			dir := tt.TempDir()
+			path := filepath.Join(dir, "collector.sock")
+			spool := filepath.Join(dir, "spool")
+
+			record := []byte(`{"level":"INFO","msg":"m1"}`)
+			data := append(record, '\n')
+			if framing == slogxnet.FramingLengthPrefix {
+				data = binary.BigEndian.AppendUint32(nil, uint32(len(record)))
+				data = append(data, record...)
+			}
+
+			t.Expect(os.WriteFile(spool, append(data, data[:len(data)-3]...), 0o600)).ToNot(HaveOccurred())
+
+			c := startCollector(t, "unix", path, nil)
+
+			opts := options(spool)
+			opts.Framing = framing
+
+			handler, err := slogxnet.NewHandler("unix", path, opts)
+			t.Expect(err).ToNot(HaveOccurred())
+			t.Expect(fileSize(t, spool)).To(Equal(int64(len(data))))
+
+			ctx, cancel := timeout()
+
+			t.Expect(slogx.Flush(ctx, handler)).ToNot(HaveOccurred())
+			slogx.New(handler).WithSource(false).Info("m2")
+			t.Expect(slogx.Close(ctx, handler)).ToNot(HaveOccurred())
+
+			if framing == slogxnet.FramingLengthPrefix {
+				t.Expect(c.readFrame(t)).To(Equal(`{"level":"INFO","msg":"m1"}`))
+				t.Expect(c.readFrame(t)).To(Equal(`{"level":"INFO","msg":"m2"}`))
+			} else {
+				t.Expect(c.readLine(t)).To(Equal(`{"level":"INFO","msg":"m1"}`))
+				t.Expect(c.readLine(t)).To(Equal(`{"level":"INFO","msg":"m2"}`))
+			}
+
+			cancel()
+			c.stop(t)
+		}
+	})
+
+	t.Run("SpoolFull", func(t Test) {
+		opts := options("")
+		opts.MaxSpoolSize = 40
+
+		handler, err := slogxnet.NewHandler("unix", filepath.Join(tt.TempDir(), "missing.sock"), opts)
+		t.Expect(err).ToNot(HaveOccurred())
+
+		defer slogx.Close(context.Background(), handler)
+
+		record := slog.NewRecord(time.Now(), slog.LevelInfo, "m1", 0)
+		t.Expect(handler.Handle(context.Background(), record)).ToNot(HaveOccurred())
+		t.Expect(handler.Handle(context.Background(), record)).To(MatchError(slogxnet.ErrSpoolFull))
+
+		ctx, cancel := timeout()
+		defer cancel()
+
+		t.Expect(errors.Is(slogx.Flush(ctx, handler), slogxnet.ErrUnavailable)).To(BeTrue())
+		t.Expect(errors.Is(slogx.Flush(context.Background(), handler), slogxnet.ErrUnavailable)).To(BeTrue())
+	})
+
+	t.Run("TLS", func(t Test) {
+		serverConfig, clientConfig := newTLSConfigs(t)
+
+		c := startCollector(t, "tcp", "127.0.0.1:0", serverConfig)
+		defer c.stop(t)
+
+		opts := options("")
+		opts.TLS = clientConfig
+		opts.Framing = slogxnet.FramingLengthPrefix
+
+		handler, err := slogxnet.NewHandler("tcp", c.listener.Addr().String(), opts)
+		t.Expect(err).ToNot(HaveOccurred())
+
+		slogx.New(handler).WithSource(false).Info("m1")
+		slogx.New(handler).WithSource(false).Info("m2")
+
+		ctx, cancel := timeout()
+		defer cancel()
+
+		t.Expect(slogx.Close(ctx, handler)).ToNot(HaveOccurred())
+		t.Expect(c.readFrame(t)).To(Equal(`{"level":"INFO","msg":"m1"}`))
+		t.Expect(c.readFrame(t)).To(Equal(`{"level":"INFO","msg":"m2"}`))
+	})
+
+	t.Run("InvalidSpool", func(t Test) {
+		_, err := slogxnet.NewHandler("unix", "x", &slogxnet.HandlerOptions{SpoolPath: tt.TempDir()})
+		t.Expect(err).To(HaveOccurred())
+	})
+}
+
+// ---
+
+// collector accepts connections and continuously reads data sent over them.
+type collector struct {
+	listener net.Listener
+	data     chan []byte
+	reader   *bufio.Reader
+	pending  []byte
+
+	mu    sync.Mutex
+	conns []net.Conn
+}
+
+func startCollector(t Test, network, address string, config *tls.Config) *collector {
+	listener, err := net.Listen(network, address)
+	t.Expect(err).ToNot(HaveOccurred())
+
+	if config != nil {
+		listener = tls.NewListener(listener, config)
+	}
+
+	c := &collector{listener: listener, data: make(chan []byte, 64)}
+	c.reader = bufio.NewReader(c)
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+
+			c.mu.Lock()
+			c.conns = append(c.conns, conn)
+			c.mu.Unlock()
+
+			go func() {
+				for {
+					buf := make([]byte, 4096)
+
+					n, err := conn.Read(buf)
+					if n != 0 {
+						c.data <- buf[:n]
+					}
+
+					if err != nil {
+						return
+					}
+				}
+			}()
+		}
+	}()
+
+	return c
+}
+
+// Read reads the data received over any of the connections.
+func (c *collector) Read(p []byte) (int, error) {
+	if len(c.pending) == 0 {
+		select {
+		case c.pending = <-c.data:
+		case <-time.After(5 * time.Second):
+			return 0, os.ErrDeadlineExceeded
+		}
+	}
+
+	n := copy(p, c.pending)
+	c.pending = c.pending[n:]
+
+	return n, nil
+}
+
+func (c *collector) readLine(t Test) string {
+	line, err := c.reader.ReadString('\n')
+	t.Expect(err).ToNot(HaveOccurred())
+	t.Expect(json.Valid([]byte(line))).To(BeTrue())
+
+	return line[:len(line)-1]
+}
+
+func (c *collector) readFrame(t Test) string {
+	var size uint32
+	t.Expect(binary.Read(c.reader, binary.BigEndian, &size)).ToNot(HaveOccurred())
+
+	buf := make([]byte, size)
+	_, err := io.ReadFull(c.reader, buf)
+	t.Expect(err).ToNot(HaveOccurred())
+
+	return string(buf)
+}
+
+func (c *collector) stop(t Test) {
+	t.Expect(c.listener.Close()).ToNot(HaveOccurred())
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, conn := range c.conns {
+		t.Expect(conn.Close()).ToNot(HaveOccurred())
+	}
+
+	c.conns = nil
+}
+
+// ---
+
+func dropTime(groups []string, attr slog.Attr) slog.Attr {
+	if len(groups) == 0 && attr.Key == slog.TimeKey {
+		return slog.Attr{}
+	}
+
+	return attr
+}
+
+func fileSize(t Test, path string) int64 {
+	info, err := os.Stat(path)
+	t.Expect(err).ToNot(HaveOccurred())
+
+	return info.Size()
+}
+
+func newTLSConfigs(t Test) (*tls.Config, *tls.Config) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	t.Expect(err).ToNot(HaveOccurred())
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		IPAddresses:  []net.IP{net.IPv4(127, 0, 0, 1)},
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	t.Expect(err).ToNot(HaveOccurred())
+
+	cert, err := x509.ParseCertificate(der)
+	t.Expect(err).ToNot(HaveOccurred())
+
+	pool := x509.NewCertPool()
+	pool.AddCert(cert)
+
+	return &tls.Config{Certificates: []tls.Certificate{{Certificate: [][]byte{der}, PrivateKey: key}}},
+		&tls.Config{RootCAs: pool}
+}
@@ -0,0 +1,173 @@
+package slogxnet
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"os"
+)
+
+// spool keeps encoded records while the connection is down.
+type spool interface {
+	Append(data []byte) error
+	ReadAt(p []byte, off int64) (int, error)
+	Reset() error
+	Size() int64
+	Close() error
+}
+
+// ---
+
+type memorySpool struct {
+	buf bytes.Buffer
+}
+
+func (s *memorySpool) Append(data []byte) error {
+	_, err := s.buf.Write(data)
+
+	return err
+}
+
+func (s *memorySpool) ReadAt(p []byte, off int64) (int, error) {
+	n := copy(p, s.buf.Bytes()[off:])
+	if n < len(p) {
+		return n, io.EOF
+	}
+
+	return n, nil
+}
+
+func (s *memorySpool) Reset() error {
+	s.buf.Reset()
+
+	return nil
+}
+
+func (s *memorySpool) Size() int64 {
+	return int64(s.buf.Len())
+}
+
+func (s *memorySpool) Close() error {
+	return nil
+}
+
+// ---
+
+// openFileSpool opens the spool file and truncates a torn record at its end, if any,
+// left by a previous run that was interrupted while appending the record.
+func openFileSpool(path string, framing Framing) (*fileSpool, error) {
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		return nil, errors.Join(err, file.Close())
+	}
+
+	size, err := completeSize(file, info.Size(), framing)
+	if err == nil && size != info.Size() {
+		err = file.Truncate(size)
+	}
+
+	if err != nil {
+		return nil, errors.Join(err, file.Close())
+	}
+
+	return &fileSpool{file, size}, nil
+}
+
+// fileSpool keeps records in a file, so records that could not be delivered survive restarts.
+type fileSpool struct {
+	file *os.File
+	size int64
+}
+
+// Append appends the data to the file.
+// If the data is written partially, the file is truncated back, so a torn record does not corrupt the spool.
+func (s *fileSpool) Append(data []byte) error {
+	n, err := s.file.Write(data)
+	if err != nil {
+		if n != 0 {
+			err = errors.Join(err, s.file.Truncate(s.size))
+		}
+
+		return err
+	}
+
+	s.size += int64(n)
+
+	return nil
+}
+
+func (s *fileSpool) ReadAt(p []byte, off int64) (int, error) {
+	return s.file.ReadAt(p, off)
+}
+
+func (s *fileSpool) Reset() error {
+	err := s.file.Truncate(0)
+	if err != nil {
+		return err
+	}
+
+	s.size = 0
+
+	return nil
+}
+
+func (s *fileSpool) Size() int64 {
+	return s.size
+}
+
+func (s *fileSpool) Close() error {
+	return s.file.Close()
+}
+
+// ---
+
+// completeSize returns the size of the longest prefix of the data consisting of whole records.
+func completeSize(r io.ReaderAt, size int64, framing Framing) (int64, error) {
+	if framing == FramingLengthPrefix {
+		var header [4]byte
+
+		off := int64(0)
+
+		for size-off >= int64(len(header)) {
+			_, err := r.ReadAt(header[:], off)
+			if err != nil {
+				return 0, err
+			}
+
+			next := off + int64(len(header)) + int64(binary.BigEndian.Uint32(header[:]))
+			if next > size {
+				break
+			}
+
+			off = next
+		}
+
+		return off, nil
+	}
+
+	buf := make([]byte, 4096)
+
+	for end := size; end > 0; {
+		start := max(end-int64(len(buf)), 0)
+		chunk := buf[:end-start]
+
+		_, err := r.ReadAt(chunk, start)
+		if err != nil {
+			return 0, err
+		}
+
+		if i := bytes.LastIndexByte(chunk, '\n'); i >= 0 {
+			return start + int64(i) + 1, nil
+		}
+
+		end = start
+	}
+
+	return 0, nil
+}
@@ -0,0 +1,236 @@
+package slogxsyslog
+
+import (
+	"cmp"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+type encoder struct {
+	options *HandlerOptions
+	buf     []byte
+}
+
+// rfc5424 encodes the message as
+// <PRI>1 TIMESTAMP HOSTNAME APP-NAME PROCID MSGID STRUCTURED-DATA MSG.
+func (e *encoder) rfc5424(record slog.Record, severity Severity, fields []field) {
+	e.priority(severity)
+	e.buf = append(e.buf, '1', ' ')
+
+	if record.Time.IsZero() {
+		e.buf = append(e.buf, '-')
+	} else {
+		e.buf = record.Time.AppendFormat(e.buf, rfc5424Time)
+	}
+
+	e.header(e.options.Hostname, 255)
+	e.header(e.options.AppName, 48)
+	e.header(e.options.ProcID, 128)
+	e.header("", 32)
+	e.buf = append(e.buf, ' ')
+
+	if len(fields) == 0 {
+		e.buf = append(e.buf, '-')
+	} else {
+		e.structuredData(fields)
+	}
+
+	if record.Message != "" {
+		e.buf = append(e.buf, ' ')
+		e.buf = append(e.buf, record.Message...)
+	}
+}
+
+// rfc3164 encodes the message as
+// <PRI>TIMESTAMP HOSTNAME TAG[PID]: MSG KEY=VALUE...
+func (e *encoder) rfc3164(record slog.Record, severity Severity, fields []field) {
+	e.priority(severity)
+
+	t := record.Time
+	if t.IsZero() {
+		t = time.Now()
+	}
+
+	e.buf = t.AppendFormat(e.buf, time.Stamp)
+	e.buf = append(e.buf, ' ')
+	e.buf = append(e.buf, cmp.Or(e.options.Hostname, "-")...)
+	e.buf = append(e.buf, ' ')
+	e.buf = append(e.buf, cmp.Or(e.options.AppName, "-")...)
+
+	if e.options.ProcID != "" {
+		e.buf = append(e.buf, '[')
+		e.buf = append(e.buf, e.options.ProcID...)
+		e.buf = append(e.buf, ']')
+	}
+
+	e.buf = append(e.buf, ':', ' ')
+	e.buf = append(e.buf, record.Message...)
+
+	for _, f := range fields {
+		e.buf = append(e.buf, ' ')
+		e.buf = append(e.buf, strings.Join(f.path, ".")...)
+		e.buf = append(e.buf, '=')
+
+		s := f.value.String()
+		if needsQuoting(s) {
+			e.buf = strconv.AppendQuote(e.buf, s)
+		} else {
+			e.buf = append(e.buf, s...)
+		}
+	}
+}
+
+func (e *encoder) priority(severity Severity) {
+	e.buf = append(e.buf, '<')
+	e.buf = strconv.AppendInt(e.buf, int64(e.options.Facility)*8+int64(severity), 10)
+	e.buf = append(e.buf, '>')
+}
+
+// header appends a space and a header field consisting of printable US-ASCII characters
+// with the given maximum length, or the nil value if the field is empty.
+func (e *encoder) header(value string, maxLen int) {
+	e.buf = append(e.buf, ' ')
+
+	if value == "" {
+		e.buf = append(e.buf, '-')
+
+		return
+	}
+
+	e.buf = appendPrintable(e.buf, value, maxLen, nil)
+}
+
+// structuredData appends the structured data elements.
+// Fields outside of any group go to the default element, and fields of each top-level group go
+// to the element named after the group, in the order of the first appearance.
+// Groups whose SD-IDs collide with the SD-ID of the default element or of a group appearing earlier,
+// for example after truncation, are merged into that element with their parameter names prefixed with the group name,
+// because RFC 5424 does not allow repeated SD-IDs.
+func (e *encoder) structuredData(fields []field) {
+	suffix := "@" + strconv.Itoa(e.options.EnterpriseNumber)
+	defaultID := sdID(e.options.DefaultElementID, suffix)
+
+	ids := make([]string, len(fields))
+	owners := make(map[string]string)
+
+	var order []string
+
+	for i, f := range fields {
+		group := elementGroup(f.path)
+
+		ids[i] = defaultID
+		if group != "" {
+			ids[i] = sdID(group, suffix)
+		}
+
+		if _, ok := owners[ids[i]]; !ok {
+			order = append(order, ids[i])
+			owners[ids[i]] = group
+
+			if ids[i] == defaultID {
+				owners[ids[i]] = ""
+			}
+		}
+	}
+
+	for _, id := range order {
+		e.buf = append(e.buf, '[')
+		e.buf = append(e.buf, id...)
+
+		for i, f := range fields {
+			if ids[i] != id {
+				continue
+			}
+
+			path := f.path
+			if group := elementGroup(path); group != "" && group == owners[id] {
+				path = path[1:]
+			}
+
+			e.buf = append(e.buf, ' ')
+			e.buf = appendPrintable(e.buf, strings.Join(path, "."), 32, isSpecialSDChar)
+			e.buf = append(e.buf, '=', '"')
+			e.buf = appendParamValue(e.buf, f.value.String())
+			e.buf = append(e.buf, '"')
+		}
+
+		e.buf = append(e.buf, ']')
+	}
+}
+
+// ---
+
+const rfc5424Time = "2006-01-02T15:04:05.000000Z07:00"
+
+// elementGroup returns the name of the top-level group of the field with the given path,
+// or an empty string if the field is outside of any group.
+func elementGroup(path []string) string {
+	if len(path) == 1 {
+		return ""
+	}
+
+	return path[0]
+}
+
+// sdID returns the SD-ID consisting of the name truncated to fit 32 characters along with the suffix.
+func sdID(name, suffix string) string {
+	return string(appendPrintable(nil, name, 32-len(suffix), isSpecialSDChar)) + suffix
+}
+
+// appendPrintable appends up to maxLen characters of the value replacing the characters
+// that are not printable US-ASCII or are special with underscores.
+func appendPrintable(buf []byte, value string, maxLen int, special func(byte) bool) []byte {
+	if len(value) > maxLen {
+		value = value[:maxLen]
+	}
+
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		if c < 33 || c > 126 || (special != nil && special(c)) {
+			c = '_'
+		}
+
+		buf = append(buf, c)
+	}
+
+	return buf
+}
+
+func isSpecialSDChar(c byte) bool {
+	return c == '=' || c == ']' || c == '"'
+}
+
+// appendParamValue appends the value escaping the characters required by RFC 5424.
+func appendParamValue(buf []byte, value string) []byte {
+	if !utf8.ValidString(value) {
+		value = strings.ToValidUTF8(value, string(utf8.RuneError))
+	}
+
+	for i := 0; i < len(value); i++ {
+		switch c := value[i]; c {
+		case '"', '\\', ']':
+			buf = append(buf, '\\', c)
+		default:
+			buf = append(buf, c)
+		}
+	}
+
+	return buf
+}
+
+func needsQuoting(s string) bool {
+	if s == "" {
+		return true
+	}
+
+	for _, r := range s {
+		if r <= ' ' || r == '=' || r == '"' || r == 0x7f {
+			return true
+		}
+	}
+
+	return false
+}
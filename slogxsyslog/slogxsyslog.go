@@ -0,0 +1,298 @@
+// Package slogxsyslog provides a [slog.Handler] sending log records to syslog
+// formatted according to RFC 5424 or RFC 3164.
+package slogxsyslog
+
+import (
+	"cmp"
+	"context"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"sync"
+
+	"github.com/pamburus/slogx"
+)
+
+// NewHandler returns a new [slog.Handler] writing each log record as a single syslog message to the writer,
+// which is usually a [Writer] returned by [Dial].
+// See [HandlerOptions] for the available options, nil options mean default options.
+func NewHandler(writer io.Writer, options *HandlerOptions) slog.Handler {
+	if options == nil {
+		options = &HandlerOptions{}
+	}
+
+	opts := *options
+
+	if opts.Hostname == "" {
+		opts.Hostname, _ = os.Hostname()
+	}
+
+	if opts.AppName == "" && len(os.Args) != 0 {
+		opts.AppName = filepath.Base(os.Args[0])
+	}
+
+	if opts.ProcID == "" {
+		opts.ProcID = strconv.Itoa(os.Getpid())
+	}
+
+	opts.Facility = cmp.Or(opts.Facility, FacilityUser)
+	opts.DefaultElementID = cmp.Or(opts.DefaultElementID, DefaultElementID)
+	opts.EnterpriseNumber = cmp.Or(opts.EnterpriseNumber, DefaultEnterpriseNumber)
+
+	return &handler{
+		shared:  &shared{writer: writer},
+		options: opts,
+	}
+}
+
+// ---
+
+// HandlerOptions contains options for [NewHandler].
+type HandlerOptions struct {
+	// Level is the minimum level of records to send, [slog.LevelInfo] is used if it is nil.
+	Level slog.Leveler
+	// Format is the syslog message format, the default is [RFC5424].
+	Format Format
+	// Facility is the facility of the messages, [FacilityUser] is used if it is zero.
+	// [FacilityKern] is reserved for kernel messages and cannot be used.
+	Facility Facility
+	// Hostname is the host name of the messages, [os.Hostname] is used if it is empty.
+	Hostname string
+	// AppName is the application name of the messages, the base name of the executable is used if it is empty.
+	AppName string
+	// ProcID is the process identifier of the messages, the process ID is used if it is empty.
+	ProcID string
+	// DefaultElementID is the name of the RFC 5424 structured data element containing the attributes
+	// outside of any group, [DefaultElementID] is used if it is empty.
+	// Each top-level group produces its own element named after the group,
+	// and nested groups are flattened into dot-separated parameter names.
+	// A group whose element name collides with the name of an element already present is merged into that element
+	// with its parameter names prefixed with the group name.
+	DefaultElementID string
+	// EnterpriseNumber is the private enterprise number appended to the names of RFC 5424 structured data elements,
+	// [DefaultEnterpriseNumber] is used if it is zero.
+	EnterpriseNumber int
+	// Severity maps levels to severities, [SeverityForLevel] is used if it is nil.
+	Severity func(slog.Level) Severity
+}
+
+// Defaults used for the corresponding [HandlerOptions] fields.
+const (
+	DefaultElementID        = "slog"
+	DefaultEnterpriseNumber = 32473 // reserved for documentation by RFC 5612
+)
+
+// ---
+
+// Format is a syslog message format.
+type Format int
+
+// Supported formats.
+const (
+	// RFC5424 is the format specified by RFC 5424, with attributes sent as structured data.
+	RFC5424 Format = iota
+	// RFC3164 is the legacy BSD format specified by RFC 3164, with attributes appended to the message as key=value pairs.
+	RFC3164
+)
+
+// ---
+
+// Facility is a syslog facility.
+type Facility int
+
+// Syslog facilities.
+const (
+	FacilityKern Facility = iota
+	FacilityUser
+	FacilityMail
+	FacilityDaemon
+	FacilityAuth
+	FacilitySyslog
+	FacilityLPR
+	FacilityNews
+	FacilityUUCP
+	FacilityCron
+	FacilityAuthPriv
+	FacilityFTP
+	_
+	_
+	_
+	_
+	FacilityLocal0
+	FacilityLocal1
+	FacilityLocal2
+	FacilityLocal3
+	FacilityLocal4
+	FacilityLocal5
+	FacilityLocal6
+	FacilityLocal7
+)
+
+// ---
+
+// Severity is a syslog severity.
+type Severity int
+
+// Syslog severities.
+const (
+	SeverityEmergency Severity = iota
+	SeverityAlert
+	SeverityCritical
+	SeverityError
+	SeverityWarning
+	SeverityNotice
+	SeverityInfo
+	SeverityDebug
+)
+
+// SeverityForLevel returns the severity corresponding to the level.
+// Levels below [slog.LevelInfo] map to [SeverityDebug], [slogx.LevelNotice] maps to [SeverityNotice],
+// [slogx.LevelCritical] maps to [SeverityCritical], [slogx.LevelFatal] and above map to [SeverityAlert],
+// and levels in between map to the severity of the nearest lower level.
+func SeverityForLevel(level slog.Level) Severity {
+	switch {
+	case level >= slogx.LevelFatal:
+		return SeverityAlert
+	case level >= slogx.LevelCritical:
+		return SeverityCritical
+	case level >= slog.LevelError:
+		return SeverityError
+	case level >= slog.LevelWarn:
+		return SeverityWarning
+	case level >= slogx.LevelNotice:
+		return SeverityNotice
+	case level >= slog.LevelInfo:
+		return SeverityInfo
+	default:
+		return SeverityDebug
+	}
+}
+
+// ---
+
+type handler struct {
+	*shared
+	options HandlerOptions
+	groups  []string
+	attrs   []field
+}
+
+type shared struct {
+	mu     sync.Mutex
+	writer io.Writer
+	buf    []byte
+}
+
+func (h *handler) Enabled(_ context.Context, level slog.Level) bool {
+	minLevel := slog.LevelInfo
+	if h.options.Level != nil {
+		minLevel = h.options.Level.Level()
+	}
+
+	return level >= minLevel
+}
+
+func (h *handler) Handle(_ context.Context, record slog.Record) error {
+	fields := slices.Clip(h.attrs)
+	record.Attrs(func(attr slog.Attr) bool {
+		fields = appendFields(fields, h.groups, attr)
+
+		return true
+	})
+
+	severity := SeverityForLevel(record.Level)
+	if h.options.Severity != nil {
+		severity = h.options.Severity(record.Level)
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	e := encoder{&h.options, h.buf[:0]}
+	if h.options.Format == RFC3164 {
+		e.rfc3164(record, severity, fields)
+	} else {
+		e.rfc5424(record, severity, fields)
+	}
+
+	h.buf = e.buf
+
+	_, err := h.writer.Write(e.buf)
+
+	return err
+}
+
+func (h *handler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+
+	h2 := *h
+	h2.attrs = slices.Clip(h.attrs)
+	for _, attr := range attrs {
+		h2.attrs = appendFields(h2.attrs, h.groups, attr)
+	}
+
+	return &h2
+}
+
+func (h *handler) WithGroup(key string) slog.Handler {
+	if key == "" {
+		return h
+	}
+
+	h2 := *h
+	h2.groups = append(slices.Clip(h.groups), key)
+
+	return &h2
+}
+
+func (h *handler) Close(context.Context) error {
+	if closer, ok := h.writer.(io.Closer); ok {
+		return closer.Close()
+	}
+
+	return nil
+}
+
+// ---
+
+// field is a resolved non-group attribute with the full path of keys leading to it.
+type field struct {
+	path  []string
+	value slog.Value
+}
+
+func appendFields(fields []field, path []string, attr slog.Attr) []field {
+	value := attr.Value.Resolve()
+
+	if value.Kind() == slog.KindGroup {
+		attrs := value.Group()
+		if len(attrs) == 0 {
+			return fields
+		}
+
+		if attr.Key != "" {
+			path = append(slices.Clip(path), attr.Key)
+		}
+
+		for _, attr := range attrs {
+			fields = appendFields(fields, path, attr)
+		}
+
+		return fields
+	}
+
+	if attr.Key == "" {
+		return fields
+	}
+
+	return append(fields, field{append(slices.Clip(path), attr.Key), value})
+}
+
+// ---
+
+var _ slogx.Closer = (*handler)(nil)
@@ -0,0 +1,333 @@
+package slogxsyslog_test
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/pamburus/go-tst/tst"
+	"github.com/pamburus/slogx"
+	"github.com/pamburus/slogx/slogxsyslog"
+)
+
+func TestHandler(tt *testing.T) {
+	t := New(tt)
+	ctx := context.Background()
+	ts := time.Date(2024, 1, 2, 3, 4, 5, 6000, time.UTC)
+
+	setup := func(options slogxsyslog.HandlerOptions) (*messages, slog.Handler) {
+		options.Hostname = "host1"
+		options.AppName = "app1"
+		options.ProcID = "42"
+
+		w := &messages{}
+
+		return w, slogxsyslog.NewHandler(w, &options)
+	}
+
+	newRecord := func(level slog.Level, msg string, attrs ...slog.Attr) slog.Record {
+		record := slog.NewRecord(ts, level, msg, 0)
+		record.AddAttrs(attrs...)
+
+		return record
+	}
+
+	t.Run("RFC5424", func(t Test) {
+		w, handler := setup(slogxsyslog.HandlerOptions{Facility: slogxsyslog.FacilityLocal0})
+
+		handler = handler.WithAttrs([]slog.Attr{slog.String("service", "s1")}).WithGroup("http")
+		t.Expect(handler.Handle(ctx, newRecord(slog.LevelWarn, "m1",
+			slog.Int("status", 500),
+			slog.Group("req", slog.String("path", `/a"b]c\d`), slog.Group("", slog.Int("n", 1))),
+		))).ToNot(HaveOccurred())
+		t.Expect(handler.Handle(ctx, newRecord(slog.LevelInfo, "m2", slog.Group("g"), slog.Attr{}))).ToNot(HaveOccurred())
+		t.Expect(slogx.New(handler).WithSource(false).WithGroup("").Enabled(ctx, slog.LevelDebug)).To(BeFalse())
+
+		t.Expect(w.values).To(Equal([]string{
+			`<132>1 2024-01-02T03:04:05.000006Z host1 app1 42 - [slog@32473 service="s1"][http@32473 status="500" req.path="/a\"b\]c\\d" req.n="1"] m1`,
+			`<134>1 2024-01-02T03:04:05.000006Z host1 app1 42 - [slog@32473 service="s1"] m2`,
+		}))
+	})
+
+	t.Run("RFC5424Collisions", func(t Test) {
+		w, handler := setup(slogxsyslog.HandlerOptions{})
+
+		long := strings.Repeat("x", 26)
+		t.Expect(handler.Handle(ctx, newRecord(slog.LevelInfo, "m1",
+			slog.Group(long+"1", slog.Int("a", 1)),
+			slog.Int("b", 2),
+			slog.Group("slog", slog.Int("c", 3)),
+			slog.Group(long+"2", slog.Int("d", 4)),
+		))).ToNot(HaveOccurred())
+
+		t.Expect(w.values).To(Equal([]string{
+			`<14>1 2024-01-02T03:04:05.000006Z host1 app1 42 - ` +
+				`[` + long + `@32473 a="1" ` + long + `2.d="4"][slog@32473 b="2" slog.c="3"] m1`,
+		}))
+	})
+
+	t.Run("RFC5424Options", func(t Test) {
+		w, handler := setup(slogxsyslog.HandlerOptions{
+			Level:            slog.LevelDebug,
+			DefaultElementID: "my app=x",
+			EnterpriseNumber: 1,
+			Severity: func(slog.Level) slogxsyslog.Severity {
+				return slogxsyslog.SeverityEmergency
+			},
+		})
+
+		t.Expect(handler.Enabled(ctx, slog.LevelDebug)).To(BeTrue())
+		t.Expect(handler.Handle(ctx, newRecord(slog.LevelDebug, "", slog.Int("a", 1)))).ToNot(HaveOccurred())
+		t.Expect(handler.Handle(ctx, slog.NewRecord(time.Time{}, slog.LevelDebug, "m1", 0))).ToNot(HaveOccurred())
+
+		t.Expect(w.values).To(Equal([]string{
+			`<8>1 2024-01-02T03:04:05.000006Z host1 app1 42 - [my_app_x@1 a="1"]`,
+			`<8>1 - host1 app1 42 - - m1`,
+		}))
+	})
+
+	t.Run("RFC3164", func(t Test) {
+		w, handler := setup(slogxsyslog.HandlerOptions{Format: slogxsyslog.RFC3164, Facility: slogxsyslog.FacilityDaemon})
+
+		handler = handler.WithGroup("g")
+		t.Expect(handler.Handle(ctx, newRecord(slog.LevelError, "m1", slog.String("a", "x y"), slog.String("b", "z"), slog.String("c", "")))).ToNot(HaveOccurred())
+
+		t.Expect(w.values).To(Equal([]string{
+			`<27>Jan  2 03:04:05 host1 app1[42]: m1 g.a="x y" g.b=z g.c=""`,
+		}))
+	})
+
+	t.Run("Close", func(t Test) {
+		w, handler := setup(slogxsyslog.HandlerOptions{})
+
+		t.Expect(slogx.Close(ctx, handler)).ToNot(HaveOccurred())
+		t.Expect(w.closed).To(BeTrue())
+		t.Expect(slogx.Close(ctx, slogxsyslog.NewHandler(io.Discard, nil))).ToNot(HaveOccurred())
+	})
+}
+
+func TestSeverityForLevel(tt *testing.T) {
+	t := New(tt)
+
+	for level, severity := range map[slog.Level]slogxsyslog.Severity{
+		slogx.LevelTrace:       slogxsyslog.SeverityDebug,
+		slog.LevelDebug:        slogxsyslog.SeverityDebug,
+		slog.LevelInfo:         slogxsyslog.SeverityInfo,
+		slog.LevelInfo + 1:     slogxsyslog.SeverityInfo,
+		slogx.LevelNotice:      slogxsyslog.SeverityNotice,
+		slog.LevelWarn:         slogxsyslog.SeverityWarning,
+		slog.LevelError:        slogxsyslog.SeverityError,
+		slogx.LevelCritical:    slogxsyslog.SeverityCritical,
+		slogx.LevelFatal:       slogxsyslog.SeverityAlert,
+		slogx.LevelFatal + 100: slogxsyslog.SeverityAlert,
+	} {
+		t.Expect(slogxsyslog.SeverityForLevel(level)).To(Equal(severity))
+	}
+}
+
+func TestDial(tt *testing.T) {
+	t := New(tt)
+
+	options := &slogxsyslog.HandlerOptions{Hostname: "host1", AppName: "app1", ProcID: "42"}
+
+	t.Run("Unixgram", func(t Test) {
+		path := filepath.Join(tt.TempDir(), "log.sock")
+
+		conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: path, Net: "unixgram"})
+		t.Expect(err).ToNot(HaveOccurred())
+
+		defer conn.Close()
+
+		w, err := slogxsyslog.Dial("unixgram", path)
+		t.Expect(err).ToNot(HaveOccurred())
+
+		logger := slogx.New(slogxsyslog.NewHandler(w, options))
+		logger.Info("m1", slog.Int("a", 1))
+		logger.Warn("m2")
+		t.Expect(w.Close()).ToNot(HaveOccurred())
+		t.Expect(w.Close()).ToNot(HaveOccurred())
+
+		_, err = w.Write([]byte("m3"))
+		t.Expect(errors.Is(err, slogxsyslog.ErrClosed)).To(BeTrue())
+
+		buf := make([]byte, 1024)
+		for _, suffix := range []string{` host1 app1 42 - [slog@32473 a="1"] m1`, ` host1 app1 42 - - m2`} {
+			n, err := conn.Read(buf)
+			t.Expect(err).ToNot(HaveOccurred())
+			t.Expect(strings.HasSuffix(string(buf[:n]), suffix)).To(BeTrue())
+		}
+	})
+
+	t.Run("TCP", func(t Test) {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		t.Expect(err).ToNot(HaveOccurred())
+
+		defer listener.Close()
+
+		conns := make(chan net.Conn, 2)
+		go func() {
+			for {
+				conn, err := listener.Accept()
+				if err != nil {
+					close(conns)
+
+					return
+				}
+
+				conns <- conn
+			}
+		}()
+
+		w, err := slogxsyslog.Dial("tcp", listener.Addr().String())
+		t.Expect(err).ToNot(HaveOccurred())
+
+		defer w.Close()
+
+		logger := slogx.New(slogxsyslog.NewHandler(w, options))
+
+		conn1 := <-conns
+		logger.Info("m1")
+		t.Expect(strings.HasSuffix(readFrame(t, bufio.NewReader(conn1)), " host1 app1 42 - - m1")).To(BeTrue())
+
+		// Writes to a connection closed by the peer may succeed until the failure is detected,
+		// so keep logging until a message arrives over a new connection.
+		t.Expect(conn1.Close()).ToNot(HaveOccurred())
+
+		var conn2 net.Conn
+		for conn2 == nil {
+			logger.Info("m2")
+
+			select {
+			case conn2 = <-conns:
+			case <-time.After(10 * time.Millisecond):
+			}
+		}
+
+		defer conn2.Close()
+
+		t.Expect(strings.HasSuffix(readFrame(t, bufio.NewReader(conn2)), " host1 app1 42 - - m2")).To(BeTrue())
+	})
+
+	t.Run("UnixStream", func(t Test) {
+		path := filepath.Join(tt.TempDir(), "log.sock")
+
+		listener, err := net.Listen("unix", path)
+		t.Expect(err).ToNot(HaveOccurred())
+
+		defer listener.Close()
+
+		w, err := slogxsyslog.Dial("unix", path)
+		t.Expect(err).ToNot(HaveOccurred())
+
+		conn, err := listener.Accept()
+		t.Expect(err).ToNot(HaveOccurred())
+
+		defer conn.Close()
+
+		logger := slogx.New(slogxsyslog.NewHandler(w, options))
+		logger.Info("m1")
+		logger.Info("m2")
+		t.Expect(w.Close()).ToNot(HaveOccurred())
+
+		data, err := io.ReadAll(conn)
+		t.Expect(err).ToNot(HaveOccurred())
+
+		lines := strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+		t.Expect(lines).To(HaveLen(2))
+		t.Expect(strings.HasPrefix(lines[0], "<14>1 ")).To(BeTrue())
+		t.Expect(strings.HasSuffix(lines[0], " host1 app1 42 - - m1")).To(BeTrue())
+		t.Expect(strings.HasSuffix(lines[1], " host1 app1 42 - - m2")).To(BeTrue())
+	})
+
+	t.Run("Unavailable", func(t Test) {
+		path := filepath.Join(tt.TempDir(), "log.sock")
+
+		listen := func() *net.UnixConn {
+			conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: path, Net: "unixgram"})
+			t.Expect(err).ToNot(HaveOccurred())
+
+			return conn
+		}
+
+		conn := listen()
+
+		w, err := slogxsyslog.Dial("unixgram", path)
+		t.Expect(err).ToNot(HaveOccurred())
+
+		defer w.Close()
+
+		t.Expect(conn.Close()).ToNot(HaveOccurred())
+		t.Expect(os.Remove(path)).ToNot(HaveOccurred())
+
+		_, err = w.Write([]byte("m1"))
+		t.Expect(err).To(HaveOccurred())
+		t.Expect(errors.Is(err, slogxsyslog.ErrUnavailable)).To(BeFalse())
+
+		conn = listen()
+		defer conn.Close()
+
+		_, err = w.Write([]byte("m2"))
+		t.Expect(errors.Is(err, slogxsyslog.ErrUnavailable)).To(BeTrue())
+
+		for errors.Is(err, slogxsyslog.ErrUnavailable) {
+			time.Sleep(10 * time.Millisecond)
+			_, err = w.Write([]byte("m3"))
+		}
+
+		t.Expect(err).ToNot(HaveOccurred())
+
+		buf := make([]byte, 1024)
+		n, err := conn.Read(buf)
+		t.Expect(err).ToNot(HaveOccurred())
+		t.Expect(string(buf[:n])).To(Equal("m3"))
+	})
+
+	t.Run("Failure", func(t Test) {
+		_, err := slogxsyslog.Dial("unixgram", filepath.Join(tt.TempDir(), "missing.sock"))
+		t.Expect(err).To(HaveOccurred())
+	})
+}
+
+// ---
+
+func readFrame(t Test, r *bufio.Reader) string {
+	size, err := r.ReadString(' ')
+	t.Expect(err).ToNot(HaveOccurred())
+
+	n, err := strconv.Atoi(strings.TrimSuffix(size, " "))
+	t.Expect(err).ToNot(HaveOccurred())
+
+	buf := make([]byte, n)
+	_, err = io.ReadFull(r, buf)
+	t.Expect(err).ToNot(HaveOccurred())
+
+	return string(buf)
+}
+
+// ---
+
+type messages struct {
+	values []string
+	closed bool
+}
+
+func (m *messages) Write(p []byte) (int, error) {
+	m.values = append(m.values, string(p))
+
+	return len(p), nil
+}
+
+func (m *messages) Close() error {
+	m.closed = true
+
+	return nil
+}
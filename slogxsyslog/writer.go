@@ -0,0 +1,194 @@
+package slogxsyslog
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Dial connects to the syslog server at the address on the named network and returns a [Writer] for it.
+// Supported networks are "unixgram", "unix", "udp", "udp4", "udp6", "tcp", "tcp4" and "tcp6".
+// If both network and address are empty, Dial connects to the local syslog daemon
+// using one of the well-known unix socket paths.
+func Dial(network, address string) (*Writer, error) {
+	w := &Writer{network: network, address: address}
+
+	err := w.connect()
+	if err != nil {
+		return nil, err
+	}
+
+	return w, nil
+}
+
+// ---
+
+// Writer writes syslog messages to a connection, one message per call to Write.
+// Messages sent over TCP connections are framed using octet counting as specified by RFC 6587,
+// messages sent over unix stream connections are terminated by a newline as local syslog daemons expect,
+// and messages sent over datagram connections are sent as separate datagrams.
+// If writing fails, the Writer reconnects and retries the write once.
+// If reconnecting fails, messages are dropped and [ErrUnavailable] is returned until the next attempt,
+// which is made after a delay starting at 100 milliseconds and doubling after each failed attempt up to 30 seconds.
+// It is safe for concurrent use.
+type Writer struct {
+	network string
+	address string
+
+	mu      sync.Mutex
+	conn    net.Conn
+	dialed  string
+	closed  bool
+	err     error
+	retryAt time.Time
+	backoff time.Duration
+}
+
+// Errors returned by [Writer.Write].
+var (
+	// ErrUnavailable is returned when the message is dropped because the syslog server could not be reached recently.
+	ErrUnavailable = errors.New("slogxsyslog: server is unavailable")
+	// ErrClosed is returned when the writer is closed.
+	ErrClosed = errors.New("slogxsyslog: writer is closed")
+)
+
+// Write sends a single syslog message.
+func (w *Writer) Write(message []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.closed {
+		return 0, ErrClosed
+	}
+
+	if w.conn != nil {
+		err := w.write(message)
+		if err == nil {
+			return len(message), nil
+		}
+
+		_ = w.conn.Close()
+		w.conn = nil
+	}
+
+	err := w.reconnect()
+	if err != nil {
+		return 0, err
+	}
+
+	err = w.write(message)
+	if err != nil {
+		_ = w.conn.Close()
+		w.conn = nil
+
+		return 0, err
+	}
+
+	return len(message), nil
+}
+
+// Close closes the connection.
+// Writes after Close fail with [ErrClosed].
+func (w *Writer) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.closed = true
+
+	if w.conn == nil {
+		return nil
+	}
+
+	err := w.conn.Close()
+	w.conn = nil
+
+	return err
+}
+
+// reconnect connects to the server unless the previous attempt failed recently.
+// It must be called with the mutex locked.
+func (w *Writer) reconnect() error {
+	now := time.Now()
+	if now.Before(w.retryAt) {
+		return fmt.Errorf("%w: %w", ErrUnavailable, w.err)
+	}
+
+	err := w.connect()
+	if err != nil {
+		w.backoff = min(max(w.backoff*2, minBackoff), maxBackoff)
+		w.retryAt = now.Add(w.backoff)
+		w.err = err
+
+		return err
+	}
+
+	w.backoff = 0
+	w.retryAt = time.Time{}
+	w.err = nil
+
+	return nil
+}
+
+func (w *Writer) connect() error {
+	if w.network != "" || w.address != "" {
+		conn, err := net.DialTimeout(w.network, w.address, dialTimeout)
+		if err != nil {
+			return err
+		}
+
+		w.conn = conn
+		w.dialed = w.network
+
+		return nil
+	}
+
+	var errs []error
+
+	for _, network := range []string{"unixgram", "unix"} {
+		for _, path := range localPaths {
+			conn, err := net.DialTimeout(network, path, dialTimeout)
+			if err == nil {
+				w.conn = conn
+				w.dialed = network
+
+				return nil
+			}
+
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// write sends the message framed as expected for the network the connection was dialed on.
+func (w *Writer) write(message []byte) error {
+	switch w.dialed {
+	case "tcp", "tcp4", "tcp6":
+		frame := make([]byte, 0, len(message)+8)
+		frame = strconv.AppendInt(frame, int64(len(message)), 10)
+		frame = append(frame, ' ')
+		message = append(frame, message...)
+	case "unix":
+		if len(message) == 0 || message[len(message)-1] != '\n' {
+			message = append(message[:len(message):len(message)], '\n')
+		}
+	}
+
+	_, err := w.conn.Write(message)
+
+	return err
+}
+
+// ---
+
+const (
+	dialTimeout = 5 * time.Second
+	minBackoff  = 100 * time.Millisecond
+	maxBackoff  = 30 * time.Second
+)
+
+var localPaths = []string{"/dev/log", "/var/run/syslog", "/var/run/log"}
@@ -0,0 +1,117 @@
+package slogxtest
+
+import (
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/pamburus/slogx"
+)
+
+// NewFakeClock returns a new [FakeClock] set to the given time.
+func NewFakeClock(now time.Time) *FakeClock {
+	return &FakeClock{now: now}
+}
+
+// ---
+
+// FakeClock is a [slogx.Clock] with manually controlled time, see [slogx.Deduplicate] for example.
+// Timers created using [FakeClock.AfterFunc] fire synchronously in the goroutine calling
+// [FakeClock.Advance] or [FakeClock.Set] once the time reaches their deadlines, in the order of the deadlines.
+type FakeClock struct {
+	mu     sync.Mutex
+	now    time.Time
+	timers []*fakeTimer
+}
+
+// Now returns the current fake time.
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.now
+}
+
+// AfterFunc creates a timer calling the function when the fake time reaches the current fake time plus the duration.
+func (c *FakeClock) AfterFunc(d time.Duration, f func()) slogx.Timer {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	timer := &fakeTimer{clock: c, deadline: c.now.Add(d), fn: f}
+	c.timers = append(c.timers, timer)
+
+	return timer
+}
+
+// Advance moves the fake time forward by the duration and fires the timers that are due.
+func (c *FakeClock) Advance(d time.Duration) {
+	c.Set(c.Now().Add(d))
+}
+
+// Set sets the fake time and fires the timers that are due.
+// Timers created by the fired functions are fired as well if they are due.
+func (c *FakeClock) Set(now time.Time) {
+	c.mu.Lock()
+	c.now = now
+	c.mu.Unlock()
+
+	for {
+		timer := c.next()
+		if timer == nil {
+			return
+		}
+
+		timer.fn()
+	}
+}
+
+func (c *FakeClock) next() *fakeTimer {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	i := -1
+	for j, timer := range c.timers {
+		if !timer.deadline.After(c.now) && (i < 0 || timer.deadline.Before(c.timers[i].deadline)) {
+			i = j
+		}
+	}
+
+	if i < 0 {
+		return nil
+	}
+
+	timer := c.timers[i]
+	c.timers = slices.Delete(c.timers, i, i+1)
+
+	return timer
+}
+
+func (c *FakeClock) stop(timer *fakeTimer) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	i := slices.Index(c.timers, timer)
+	if i < 0 {
+		return false
+	}
+
+	c.timers = slices.Delete(c.timers, i, i+1)
+
+	return true
+}
+
+// ---
+
+type fakeTimer struct {
+	clock    *FakeClock
+	deadline time.Time
+	fn       func()
+}
+
+func (t *fakeTimer) Stop() bool {
+	return t.clock.stop(t)
+}
+
+// ---
+
+var _ slogx.Clock = (*FakeClock)(nil)
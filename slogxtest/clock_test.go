@@ -0,0 +1,38 @@
+package slogxtest_test
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/pamburus/go-tst/tst"
+	"github.com/pamburus/slogx/slogxtest"
+)
+
+func TestFakeClock(tt *testing.T) {
+	t := New(tt)
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := slogxtest.NewFakeClock(start)
+
+	var fired []string
+
+	clock.AfterFunc(2*time.Second, func() { fired = append(fired, "t2") })
+	clock.AfterFunc(time.Second, func() {
+		fired = append(fired, "t1")
+		clock.AfterFunc(0, func() { fired = append(fired, "t1.0") })
+	})
+	t3 := clock.AfterFunc(3*time.Second, func() { fired = append(fired, "t3") })
+
+	clock.Advance(500 * time.Millisecond)
+	t.Expect(fired).To(HaveLen(0))
+	t.Expect(clock.Now()).To(Equal(start.Add(500 * time.Millisecond)))
+
+	clock.Advance(2 * time.Second)
+	t.Expect(fired).To(Equal([]string{"t1", "t2", "t1.0"}))
+
+	t.Expect(t3.Stop()).To(BeTrue())
+	t.Expect(t3.Stop()).To(BeFalse())
+
+	clock.Set(start.Add(time.Hour))
+	t.Expect(fired).To(HaveLen(3))
+}
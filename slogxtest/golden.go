@@ -0,0 +1,249 @@
+package slogxtest
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"log/slog"
+	"math"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/pamburus/slogx"
+)
+
+// NewRecorder returns a new [Recorder].
+func NewRecorder() *Recorder {
+	return &Recorder{state: &recorderState{}}
+}
+
+// ---
+
+// Recorder is a [slog.Handler] capturing all log records
+// along with the attributes and groups added to the handler using [slog.Handler.WithAttrs] and [slog.Handler.WithGroup].
+// Captured records can be serialized using [Recorder.Snapshot] and compared against golden files using [AssertGolden].
+type Recorder struct {
+	state *recorderState
+	ops   []handlerOp
+}
+
+// Enabled returns true for all levels.
+func (r *Recorder) Enabled(context.Context, slog.Level) bool {
+	return true
+}
+
+// Handle captures the record.
+func (r *Recorder) Handle(_ context.Context, record slog.Record) error {
+	r.state.mu.Lock()
+	defer r.state.mu.Unlock()
+
+	r.state.entries = append(r.state.entries, recorderEntry{r.ops, record.Clone()})
+
+	return nil
+}
+
+// WithAttrs returns a new [Recorder] sharing the captured records, with the given attributes.
+func (r *Recorder) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return r
+	}
+
+	return &Recorder{r.state, append(slices.Clip(r.ops), handlerOp{attrs: slices.Clone(attrs)})}
+}
+
+// WithGroup returns a new [Recorder] sharing the captured records, with the given group.
+func (r *Recorder) WithGroup(key string) slog.Handler {
+	if key == "" {
+		return r
+	}
+
+	return &Recorder{r.state, append(slices.Clip(r.ops), handlerOp{group: key})}
+}
+
+// Records returns the captured records without the attributes added to the handler.
+func (r *Recorder) Records() []slog.Record {
+	r.state.mu.Lock()
+	defer r.state.mu.Unlock()
+
+	records := make([]slog.Record, len(r.state.entries))
+	for i, entry := range r.state.entries {
+		records[i] = entry.record.Clone()
+	}
+
+	return records
+}
+
+// Reset discards the captured records.
+func (r *Recorder) Reset() {
+	r.state.mu.Lock()
+	defer r.state.mu.Unlock()
+
+	r.state.entries = nil
+}
+
+// Snapshot returns the captured records serialized as JSON lines in a deterministic form suitable for golden files.
+// Record time is omitted, source location is rendered as the function name only,
+// and levels are rendered using [slogx.LevelName].
+// Volatile attribute values can be normalized using the rules, which are applied in the given order,
+// see [NormalizeKeys], [NormalizeDurations], [NormalizeTimes] and [NormalizePattern].
+func (r *Recorder) Snapshot(rules ...slogx.AttrReplacer) []byte {
+	var buf bytes.Buffer
+
+	builder := slogx.TweakHandler(slog.NewJSONHandler(&buf, &slog.HandlerOptions{
+		AddSource:   true,
+		Level:       slog.Level(math.MinInt),
+		ReplaceAttr: replaceSnapshotAttr,
+	}))
+
+	for _, rule := range rules {
+		builder = builder.WithAttrReplacer(rule)
+	}
+
+	base := builder.Result()
+
+	r.state.mu.Lock()
+	defer r.state.mu.Unlock()
+
+	for _, entry := range r.state.entries {
+		handler := base
+		for _, op := range entry.ops {
+			if op.group != "" {
+				handler = handler.WithGroup(op.group)
+			} else {
+				handler = handler.WithAttrs(op.attrs)
+			}
+		}
+
+		_ = handler.Handle(context.Background(), entry.record)
+	}
+
+	return buf.Bytes()
+}
+
+// ---
+
+// NormalizeKeys returns a rule replacing values of attributes with any of the given keys in any group with the placeholder.
+func NormalizeKeys(placeholder string, keys ...string) slogx.AttrReplacer {
+	return func(_ []string, attr slog.Attr) slog.Attr {
+		if slices.Contains(keys, attr.Key) && attr.Value.Kind() != slog.KindGroup {
+			attr.Value = slog.StringValue(placeholder)
+		}
+
+		return attr
+	}
+}
+
+// NormalizeDurations returns a rule replacing all [time.Duration] values with the placeholder.
+func NormalizeDurations(placeholder string) slogx.AttrReplacer {
+	return normalizeKind(slog.KindDuration, placeholder)
+}
+
+// NormalizeTimes returns a rule replacing all [time.Time] values with the placeholder.
+func NormalizeTimes(placeholder string) slogx.AttrReplacer {
+	return normalizeKind(slog.KindTime, placeholder)
+}
+
+// NormalizePattern returns a rule replacing all matches of the pattern in string values with the placeholder.
+// See [UUIDPattern] for a commonly used pattern.
+func NormalizePattern(pattern *regexp.Regexp, placeholder string) slogx.AttrReplacer {
+	return func(_ []string, attr slog.Attr) slog.Attr {
+		if attr.Value.Kind() == slog.KindString {
+			attr.Value = slog.StringValue(pattern.ReplaceAllLiteralString(attr.Value.String(), placeholder))
+		}
+
+		return attr
+	}
+}
+
+// UUIDPattern matches UUIDs in their canonical textual representation.
+var UUIDPattern = regexp.MustCompile(`(?i)\b[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}\b`)
+
+// ---
+
+// AssertGolden compares the data with the contents of the golden file at the path
+// and reports an error if they differ.
+// If the test binary is run with -slogxtest.update flag, the golden file is written instead.
+func AssertGolden(t testing.TB, path string, data []byte) {
+	t.Helper()
+
+	if *update {
+		err := os.MkdirAll(filepath.Dir(path), 0o755)
+		if err == nil {
+			err = os.WriteFile(path, data, 0o644)
+		}
+
+		if err != nil {
+			t.Fatalf("slogxtest: failed to update golden file: %v", err)
+		}
+
+		return
+	}
+
+	expected, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("slogxtest: failed to read golden file, run with -slogxtest.update flag to create it: %v", err)
+
+		return
+	}
+
+	if !bytes.Equal(expected, data) {
+		t.Errorf("slogxtest: output does not match golden file %s, run with -slogxtest.update flag to update it\nexpected:\n%s\nactual:\n%s", path, expected, data)
+	}
+}
+
+// ---
+
+var update = flag.Bool("slogxtest.update", false, "update golden files compared by slogxtest.AssertGolden")
+
+// ---
+
+type recorderState struct {
+	mu      sync.Mutex
+	entries []recorderEntry
+}
+
+type recorderEntry struct {
+	ops    []handlerOp
+	record slog.Record
+}
+
+type handlerOp struct {
+	group string
+	attrs []slog.Attr
+}
+
+// ---
+
+func normalizeKind(kind slog.Kind, placeholder string) slogx.AttrReplacer {
+	return func(_ []string, attr slog.Attr) slog.Attr {
+		if attr.Value.Kind() == kind {
+			attr.Value = slog.StringValue(placeholder)
+		}
+
+		return attr
+	}
+}
+
+func replaceSnapshotAttr(groups []string, attr slog.Attr) slog.Attr {
+	if len(groups) != 0 {
+		return attr
+	}
+
+	switch attr.Key {
+	case slog.TimeKey:
+		return slog.Attr{}
+	case slog.SourceKey:
+		source, ok := attr.Value.Any().(*slog.Source)
+		if !ok || source.Function == "" {
+			return slog.Attr{}
+		}
+
+		return slog.String(slog.SourceKey, source.Function)
+	}
+
+	return slogx.ReplaceLevelAttr(groups, attr)
+}
@@ -0,0 +1,61 @@
+package slogxtest_test
+
+import (
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	. "github.com/pamburus/go-tst/tst"
+	"github.com/pamburus/slogx"
+	"github.com/pamburus/slogx/slogxtest"
+)
+
+func TestRecorder(tt *testing.T) {
+	t := New(tt)
+
+	recorder := slogxtest.NewRecorder()
+	logger := slogx.New(recorder)
+
+	logger.Info("m1", slog.Int("a", 1))
+	logger.WithGroup("g").With(slog.String("b", "x")).WithLongTerm(slog.String("c", "y")).Warn("m2", slog.Int("d", 2))
+	logger.Log(slogx.LevelNotice, "m3",
+		slog.String("request", "req 0e7e9a4a-6ea4-4b43-9c1c-5c4d61c7f7b9 done"),
+		slog.Duration("elapsed", 42*time.Millisecond),
+		slog.Time("started", time.Now()),
+		slog.Group("user", slog.String("token", "secret")),
+	)
+
+	t.Expect(recorder.Records()).To(HaveLen(3))
+
+	snapshot := recorder.Snapshot(
+		slogxtest.NormalizeKeys("<token>", "token"),
+		slogxtest.NormalizeDurations("<duration>"),
+		slogxtest.NormalizeTimes("<time>"),
+		slogxtest.NormalizePattern(slogxtest.UUIDPattern, "<uuid>"),
+	)
+
+	slogxtest.AssertGolden(tt, filepath.Join("testdata", "recorder.golden.jsonl"), snapshot)
+
+	recorder.Reset()
+	t.Expect(recorder.Records()).To(HaveLen(0))
+	t.Expect(recorder.Snapshot()).To(HaveLen(0))
+}
+
+func TestAssertGolden(tt *testing.T) {
+	t := New(tt)
+
+	path := filepath.Join(tt.TempDir(), "golden.jsonl")
+	t.Expect(os.WriteFile(path, []byte("a\n"), 0o644)).ToNot(HaveOccurred())
+
+	ft := &fakeT{TB: tt}
+	slogxtest.AssertGolden(ft, path, []byte("a\n"))
+	t.Expect(ft.errors).To(HaveLen(0))
+
+	slogxtest.AssertGolden(ft, path, []byte("b\n"))
+	t.Expect(ft.errors).To(HaveLen(1))
+
+	slogxtest.AssertGolden(ft, filepath.Join(tt.TempDir(), "missing.jsonl"), nil)
+	t.Expect(ft.errors).To(HaveLen(2))
+}
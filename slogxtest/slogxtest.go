@@ -0,0 +1,185 @@
+// Package slogxtest provides helpers for using [slogx] in tests.
+package slogxtest
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/pamburus/slogx"
+	"github.com/pamburus/slogx/slogc"
+)
+
+// NewT returns a new [slogx.Logger] sending its output to the test log using [testing.TB.Log].
+// See [NewTHandler] for details.
+func NewT(t testing.TB) *slogx.Logger {
+	return slogx.New(NewTHandler(t, nil))
+}
+
+// NewContext returns a new context containing a [slogc.Logger] sending its output to the test log.
+// It allows parallel tests using context loggers to keep their output separate.
+// See [NewTHandler] for details.
+func NewContext(t testing.TB) context.Context {
+	return slogc.New(context.Background(), NewT(t).ContextLogger())
+}
+
+// NewTHandler returns a new [slog.Handler] sending its output to the test log.
+// Records are rendered in text format without time and with the source location of the logging call,
+// so logging helper functions marked using [slogx.Helper] are skipped.
+// Since Go 1.25, records are written using the Output method of [testing.T] which does not prefix the lines
+// with a source location. Older versions of Go lack the method, so [testing.TB.Log] is used instead,
+// and it prefixes each line with the location inside the handler rather than the location of the logging call.
+// The handler panics if it handles a record after the test has completed,
+// which usually indicates a goroutine leaked from the test.
+// See [HandlerOptions] for the available options, nil options mean default options.
+func NewTHandler(t testing.TB, options *HandlerOptions) slog.Handler {
+	if options == nil {
+		options = &HandlerOptions{}
+	}
+
+	level := options.Level
+	if level == nil {
+		level = slog.LevelDebug
+	}
+
+	s := &tState{t: t, buffered: options.Buffered}
+	if t, ok := t.(testOutput); ok {
+		s.out = t.Output()
+	}
+
+	t.Cleanup(s.complete)
+
+	return &tHandler{
+		state: s,
+		base: slog.NewTextHandler(&s.buf, &slog.HandlerOptions{
+			AddSource:   true,
+			Level:       level,
+			ReplaceAttr: replaceAttr,
+		}),
+	}
+}
+
+// ---
+
+// HandlerOptions contains options for the handler returned by [NewTHandler].
+type HandlerOptions struct {
+	// Level is the minimum level of the records to be logged, [slog.LevelDebug] is used if it is nil.
+	Level slog.Leveler
+	// Buffered specifies whether to buffer the output and send it to the test log only if the test fails.
+	Buffered bool
+}
+
+// ---
+
+type tHandler struct {
+	state *tState
+	base  slog.Handler
+}
+
+func (h *tHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.base.Enabled(ctx, level)
+}
+
+func (h *tHandler) Handle(ctx context.Context, record slog.Record) error {
+	s := h.state
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.done {
+		panic(fmt.Sprintf("slogxtest: log record %q is handled after test %s has completed", record.Message, s.t.Name()))
+	}
+
+	err := h.base.Handle(ctx, record)
+	line := strings.TrimSuffix(s.buf.String(), "\n")
+	s.buf.Reset()
+
+	if s.buffered {
+		s.lines = append(s.lines, line)
+	} else {
+		s.log(line)
+	}
+
+	return err
+}
+
+func (h *tHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &tHandler{h.state, h.base.WithAttrs(attrs)}
+}
+
+func (h *tHandler) WithGroup(key string) slog.Handler {
+	return &tHandler{h.state, h.base.WithGroup(key)}
+}
+
+// ---
+
+type tState struct {
+	t        testing.TB
+	out      io.Writer
+	buffered bool
+	mu       sync.Mutex
+	buf      bytes.Buffer
+	lines    []string
+	done     bool
+}
+
+func (s *tState) complete() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.t.Failed() {
+		for _, line := range s.lines {
+			s.log(line)
+		}
+	}
+
+	s.lines = nil
+	s.done = true
+}
+
+func (s *tState) log(line string) {
+	if s.out != nil {
+		_, _ = io.WriteString(s.out, line+"\n")
+
+		return
+	}
+
+	s.t.Helper()
+	s.t.Log(line)
+}
+
+// ---
+
+// testOutput is implemented by [testing.T], [testing.B] and [testing.F] since Go 1.25.
+type testOutput interface {
+	Output() io.Writer
+}
+
+// ---
+
+func replaceAttr(groups []string, attr slog.Attr) slog.Attr {
+	if len(groups) != 0 {
+		return attr
+	}
+
+	switch attr.Key {
+	case slog.TimeKey:
+		return slog.Attr{}
+	case slog.SourceKey:
+		source, ok := attr.Value.Any().(*slog.Source)
+		if !ok || source.File == "" {
+			return slog.Attr{}
+		}
+
+		return slog.String(slog.SourceKey, filepath.Base(source.File)+":"+strconv.Itoa(source.Line))
+	}
+
+	return slogx.ReplaceLevelAttr(groups, attr)
+}
@@ -0,0 +1,174 @@
+package slogxtest_test
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"testing"
+
+	. "github.com/pamburus/go-tst/tst"
+	"github.com/pamburus/slogx"
+	"github.com/pamburus/slogx/slogc"
+	"github.com/pamburus/slogx/slogxtest"
+)
+
+func TestNewTHandler(tt *testing.T) {
+	t := New(tt)
+
+	t.Run("Unbuffered", func(t Test) {
+		ft := &fakeT{TB: tt}
+		logger := slogxtest.NewT(ft)
+
+		_, _, line, _ := runtime.Caller(0)
+		logger.Debug("m1", slog.Int("a", 1))
+		logger.WithGroup("g").With(slog.String("b", "x")).Trace("m2")
+		logHelper(logger)
+
+		t.Expect(ft.logs).To(Equal([]string{
+			fmt.Sprintf("level=DEBUG source=slogxtest_test.go:%d msg=m1 a=1", line+1),
+			fmt.Sprintf("level=WARN source=slogxtest_test.go:%d msg=m3", line+3),
+		}))
+
+		ft.complete()
+		t.Expect(recovered(func() { logger.Info("m4") })).ToNot(BeNil())
+	})
+
+	t.Run("Buffered", func(t Test) {
+		ft := &fakeT{TB: tt}
+		logger := slogx.New(slogxtest.NewTHandler(ft, &slogxtest.HandlerOptions{
+			Level:    slogx.LevelTrace,
+			Buffered: true,
+		})).WithSource(false)
+
+		logger.Trace("m1")
+		logger.Info("m2")
+		t.Expect(ft.logs).To(HaveLen(0))
+
+		ft.failed = true
+		ft.complete()
+		t.Expect(ft.logs).To(Equal([]string{
+			"level=TRACE msg=m1",
+			"level=INFO msg=m2",
+		}))
+	})
+
+	t.Run("BufferedPassed", func(t Test) {
+		ft := &fakeT{TB: tt}
+		logger := slogx.New(slogxtest.NewTHandler(ft, &slogxtest.HandlerOptions{Buffered: true}))
+
+		logger.Info("m1")
+		ft.complete()
+		t.Expect(ft.logs).To(HaveLen(0))
+	})
+
+	t.Run("Context", func(t Test) {
+		ft := &fakeT{TB: tt}
+		ctx := slogc.WithSource(slogxtest.NewContext(ft), false)
+
+		slogc.Info(ctx, "m1")
+		t.Expect(ft.logs).To(Equal([]string{"level=INFO msg=m1"}))
+	})
+}
+
+func TestNewTHandlerOutput(tt *testing.T) {
+	t := New(tt)
+
+	_, _, line, _ := runtime.Caller(0)
+	if os.Getenv("SLOGXTEST_OUTPUT") == "1" {
+		logger := slogxtest.NewT(tt)
+		logger.Info("m1", slog.Int("a", 1))
+		logHelper(logger)
+
+		return
+	}
+
+	if _, ok := any(tt).(interface{ Output() io.Writer }); !ok {
+		tt.Skip("testing.T has no Output method in this version of Go")
+	}
+
+	cmd := exec.Command(os.Args[0], "-test.run=^TestNewTHandlerOutput$", "-test.v")
+	cmd.Env = append(os.Environ(), "SLOGXTEST_OUTPUT=1")
+	output, err := cmd.Output()
+	t.Expect(err).ToNot(HaveOccurred())
+
+	lines := strings.Split(string(output), "\n")
+	t.Expect(lines[1:3]).To(Equal([]string{
+		fmt.Sprintf("    level=INFO source=slogxtest_test.go:%d msg=m1 a=1", line+3),
+		fmt.Sprintf("    level=WARN source=slogxtest_test.go:%d msg=m3", line+4),
+	}))
+}
+
+// ---
+
+func logHelper(logger *slogx.Logger) {
+	slogx.Helper()
+	logger.Warn("m3")
+}
+
+func recovered(f func()) (value any) {
+	defer func() {
+		value = recover()
+	}()
+
+	f()
+
+	return nil
+}
+
+// ---
+
+type fakeT struct {
+	testing.TB
+	logs     []string
+	errors   []string
+	cleanups []func()
+	failed   bool
+}
+
+func (t *fakeT) Helper() {}
+
+func (t *fakeT) Output() io.Writer {
+	return fakeOutput{t}
+}
+
+func (t *fakeT) Log(args ...any) {
+	t.logs = append(t.logs, args[0].(string))
+}
+
+func (t *fakeT) Errorf(format string, args ...any) {
+	t.errors = append(t.errors, fmt.Sprintf(format, args...))
+}
+
+func (t *fakeT) Fatalf(format string, args ...any) {
+	t.Errorf(format, args...)
+}
+
+func (t *fakeT) Cleanup(f func()) {
+	t.cleanups = append(t.cleanups, f)
+}
+
+func (t *fakeT) Failed() bool {
+	return t.failed
+}
+
+func (t *fakeT) complete() {
+	for i := len(t.cleanups) - 1; i >= 0; i-- {
+		t.cleanups[i]()
+	}
+}
+
+// ---
+
+type fakeOutput struct {
+	t *fakeT
+}
+
+func (o fakeOutput) Write(p []byte) (int, error) {
+	o.t.logs = append(o.t.logs, strings.TrimSuffix(string(p), "\n"))
+
+	return len(p), nil
+}
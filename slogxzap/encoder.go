@@ -0,0 +1,158 @@
+package slogxzap
+
+import (
+	"encoding/base64"
+	"log/slog"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+func newEncoder() *encoder {
+	return &encoder{groups: []groupAttrs{{}}}
+}
+
+// ---
+
+// encoder is a [zapcore.ObjectEncoder] collecting fields as attributes.
+// Each namespace opens a new group that contains all subsequent fields.
+type encoder struct {
+	groups []groupAttrs
+}
+
+func (e *encoder) add(attr slog.Attr) {
+	group := &e.groups[len(e.groups)-1]
+	group.attrs = append(group.attrs, attr)
+}
+
+func (e *encoder) collect() []slog.Attr {
+	attrs := e.groups[len(e.groups)-1].attrs
+
+	for i := len(e.groups) - 1; i > 0; i-- {
+		group := slog.Attr{Key: e.groups[i].key, Value: slog.GroupValue(attrs...)}
+		attrs = append(e.groups[i-1].attrs, group)
+	}
+
+	return attrs
+}
+
+func (e *encoder) AddArray(key string, value zapcore.ArrayMarshaler) error {
+	m := zapcore.NewMapObjectEncoder()
+	err := m.AddArray(key, value)
+	e.add(slog.Any(key, m.Fields[key]))
+
+	return err
+}
+
+func (e *encoder) AddObject(key string, value zapcore.ObjectMarshaler) error {
+	sub := newEncoder()
+	err := value.MarshalLogObject(sub)
+	e.add(slog.Attr{Key: key, Value: slog.GroupValue(sub.collect()...)})
+
+	return err
+}
+
+func (e *encoder) AddBinary(key string, value []byte) {
+	e.add(slog.String(key, base64.StdEncoding.EncodeToString(value)))
+}
+
+func (e *encoder) AddByteString(key string, value []byte) {
+	e.add(slog.String(key, string(value)))
+}
+
+func (e *encoder) AddBool(key string, value bool) {
+	e.add(slog.Bool(key, value))
+}
+
+func (e *encoder) AddComplex128(key string, value complex128) {
+	e.add(slog.Any(key, value))
+}
+
+func (e *encoder) AddComplex64(key string, value complex64) {
+	e.add(slog.Any(key, value))
+}
+
+func (e *encoder) AddDuration(key string, value time.Duration) {
+	e.add(slog.Duration(key, value))
+}
+
+func (e *encoder) AddFloat64(key string, value float64) {
+	e.add(slog.Float64(key, value))
+}
+
+func (e *encoder) AddFloat32(key string, value float32) {
+	e.add(slog.Float64(key, float64(value)))
+}
+
+func (e *encoder) AddInt(key string, value int) {
+	e.add(slog.Int(key, value))
+}
+
+func (e *encoder) AddInt64(key string, value int64) {
+	e.add(slog.Int64(key, value))
+}
+
+func (e *encoder) AddInt32(key string, value int32) {
+	e.add(slog.Int64(key, int64(value)))
+}
+
+func (e *encoder) AddInt16(key string, value int16) {
+	e.add(slog.Int64(key, int64(value)))
+}
+
+func (e *encoder) AddInt8(key string, value int8) {
+	e.add(slog.Int64(key, int64(value)))
+}
+
+func (e *encoder) AddString(key, value string) {
+	e.add(slog.String(key, value))
+}
+
+func (e *encoder) AddTime(key string, value time.Time) {
+	e.add(slog.Time(key, value))
+}
+
+func (e *encoder) AddUint(key string, value uint) {
+	e.add(slog.Uint64(key, uint64(value)))
+}
+
+func (e *encoder) AddUint64(key string, value uint64) {
+	e.add(slog.Uint64(key, value))
+}
+
+func (e *encoder) AddUint32(key string, value uint32) {
+	e.add(slog.Uint64(key, uint64(value)))
+}
+
+func (e *encoder) AddUint16(key string, value uint16) {
+	e.add(slog.Uint64(key, uint64(value)))
+}
+
+func (e *encoder) AddUint8(key string, value uint8) {
+	e.add(slog.Uint64(key, uint64(value)))
+}
+
+func (e *encoder) AddUintptr(key string, value uintptr) {
+	e.add(slog.Uint64(key, uint64(value)))
+}
+
+func (e *encoder) AddReflected(key string, value any) error {
+	e.add(slog.Any(key, value))
+
+	return nil
+}
+
+func (e *encoder) OpenNamespace(key string) {
+	e.groups = append(e.groups, groupAttrs{key: key})
+}
+
+// ---
+
+type groupAttrs struct {
+	key   string
+	attrs []slog.Attr
+}
+
+// ---
+
+var _ zapcore.ObjectEncoder = (*encoder)(nil)
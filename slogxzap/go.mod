@@ -0,0 +1,13 @@
+module github.com/pamburus/slogx/slogxzap
+
+go 1.22
+
+replace github.com/pamburus/slogx => ../
+
+require (
+	github.com/pamburus/go-tst v0.6.0
+	github.com/pamburus/slogx v0.0.0
+	go.uber.org/zap v1.27.0
+)
+
+require go.uber.org/multierr v1.10.0 // indirect
@@ -0,0 +1,134 @@
+// Package slogxzap provides a [zapcore.Core] implementation backed by [slog.Handler].
+package slogxzap
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/pamburus/slogx"
+	"github.com/pamburus/slogx/slogc"
+	"go.uber.org/zap/zapcore"
+)
+
+// NewCore returns a new [zapcore.Core] that sends log entries to the handler.
+//
+// Levels are mapped to [slog.Level] values as follows:
+//   - [zapcore.DebugLevel], [zapcore.InfoLevel], [zapcore.WarnLevel] and [zapcore.ErrorLevel]
+//     are mapped to the corresponding standard levels;
+//   - [zapcore.DPanicLevel] and [zapcore.PanicLevel] are mapped to [slogx.LevelCritical];
+//   - [zapcore.FatalLevel] is mapped to [slogx.LevelFatal].
+//
+// Namespaces are mapped to groups.
+// Logger names are stored in the context passed to the handler using [slogc.WithName],
+// so they can be used by [slogc.NameAttr] and [slogc.NameLevel].
+// The caller is used as the source location if it is enabled in the [zap.Logger],
+// and the stack trace is added as [slogx.StackKey] attribute.
+// Sync flushes the handler using [slogx.Flush].
+func NewCore(handler slog.Handler) zapcore.Core {
+	return &core{handler: handler}
+}
+
+// ---
+
+type core struct {
+	handler slog.Handler
+}
+
+func (c *core) Enabled(level zapcore.Level) bool {
+	return c.handler.Enabled(context.Background(), slogLevel(level))
+}
+
+func (c *core) With(fields []zapcore.Field) zapcore.Core {
+	if len(fields) == 0 {
+		return c
+	}
+
+	enc := newEncoder()
+	for _, field := range fields {
+		addField(enc, field)
+	}
+
+	handler := c.handler
+	for i, group := range enc.groups {
+		if i != 0 {
+			handler = handler.WithGroup(group.key)
+		}
+
+		if len(group.attrs) != 0 {
+			handler = handler.WithAttrs(group.attrs)
+		}
+	}
+
+	return &core{handler: handler}
+}
+
+func (c *core) Check(entry zapcore.Entry, checked *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.handler.Enabled(entryContext(entry), slogLevel(entry.Level)) {
+		return checked.AddCore(entry, c)
+	}
+
+	return checked
+}
+
+func (c *core) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	var pc uintptr
+	if entry.Caller.Defined {
+		pc = entry.Caller.PC
+	}
+
+	record := slog.NewRecord(entry.Time, slogLevel(entry.Level), entry.Message, pc)
+
+	enc := newEncoder()
+	for _, field := range fields {
+		addField(enc, field)
+	}
+
+	if entry.Stack != "" {
+		enc.groups[0].attrs = append(enc.groups[0].attrs, slog.String(slogx.StackKey, entry.Stack))
+	}
+
+	record.AddAttrs(enc.collect()...)
+
+	return c.handler.Handle(entryContext(entry), record)
+}
+
+func (c *core) Sync() error {
+	return slogx.Flush(context.Background(), c.handler)
+}
+
+// ---
+
+func slogLevel(level zapcore.Level) slog.Level {
+	switch level {
+	case zapcore.DebugLevel:
+		return slog.LevelDebug
+	case zapcore.InfoLevel:
+		return slog.LevelInfo
+	case zapcore.WarnLevel:
+		return slog.LevelWarn
+	case zapcore.ErrorLevel:
+		return slog.LevelError
+	case zapcore.DPanicLevel, zapcore.PanicLevel:
+		return slogx.LevelCritical
+	case zapcore.FatalLevel:
+		return slogx.LevelFatal
+	default:
+		return slog.Level(level) * 4
+	}
+}
+
+func entryContext(entry zapcore.Entry) context.Context {
+	return slogc.WithName(context.Background(), entry.LoggerName)
+}
+
+func addField(enc *encoder, field zapcore.Field) {
+	switch field.Type {
+	case zapcore.ErrorType:
+		if err, ok := field.Interface.(error); ok {
+			enc.add(slog.Any(field.Key, err))
+		}
+	case zapcore.SkipType:
+	default:
+		field.AddTo(enc)
+	}
+}
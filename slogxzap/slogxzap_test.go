@@ -0,0 +1,139 @@
+package slogxzap_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/pamburus/go-tst/tst"
+	"github.com/pamburus/slogx"
+	"github.com/pamburus/slogx/slogc"
+	"github.com/pamburus/slogx/slogxzap"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestCore(tt *testing.T) {
+	t := New(tt)
+
+	setup := func(t Test, options ...zap.Option) (func() []map[string]any, *zap.Logger) {
+		var buf bytes.Buffer
+
+		handler := slogx.TweakHandler(slog.NewJSONHandler(&buf, &slog.HandlerOptions{
+			AddSource: true,
+			Level:     slog.LevelDebug,
+			ReplaceAttr: func(groups []string, attr slog.Attr) slog.Attr {
+				switch {
+				case len(groups) == 0 && attr.Key == slog.TimeKey:
+					return slog.Attr{}
+				case len(groups) == 0 && attr.Key == slog.SourceKey:
+					if function := attr.Value.Any().(*slog.Source).Function; function != "" {
+						return slog.String(slog.SourceKey, function)
+					}
+
+					return slog.Attr{}
+				}
+
+				return slogx.ReplaceLevelAttr(groups, attr)
+			},
+		})).WithDynamicAttr(slogc.NameAttr("logger")).Result()
+
+		logger := zap.New(slogxzap.NewCore(handler), options...)
+
+		records := func() []map[string]any {
+			var result []map[string]any
+
+			for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+				var record map[string]any
+				t.Expect(json.Unmarshal([]byte(line), &record)).ToNot(HaveOccurred())
+
+				result = append(result, record)
+			}
+
+			return result
+		}
+
+		return records, logger
+	}
+
+	t.Run("Fields", func(t Test) {
+		records, logger := setup(t)
+
+		logger.Info("m1",
+			zap.String("s", "v"),
+			zap.Int("i", 1),
+			zap.Uint8("u", 2),
+			zap.Bool("b", true),
+			zap.Duration("d", time.Second),
+			zap.Error(errors.New("e1")),
+			zap.Error(nil),
+			zap.Strings("ss", []string{"x", "y"}),
+			zap.Object("o", zapcore.ObjectMarshalerFunc(func(enc zapcore.ObjectEncoder) error {
+				enc.AddString("k", "v")
+
+				return nil
+			})),
+			zap.Namespace("ns"),
+			zap.Float64("f", 1.5),
+		)
+
+		t.Expect(records()).To(Equal([]map[string]any{{
+			"level": "INFO",
+			"msg":   "m1",
+			"s":     "v",
+			"i":     float64(1),
+			"u":     float64(2),
+			"b":     true,
+			"d":     float64(time.Second),
+			"error": "e1",
+			"ss":    []any{"x", "y"},
+			"o":     map[string]any{"k": "v"},
+			"ns":    map[string]any{"f": 1.5},
+		}}))
+	})
+
+	t.Run("With", func(t Test) {
+		records, logger := setup(t)
+
+		logger = logger.With(zap.String("a", "1"), zap.Namespace("g"), zap.String("b", "2")).Named("n1").Named("n2")
+		logger.Warn("m1", zap.String("c", "3"))
+		logger.Debug("m2")
+
+		t.Expect(records()).To(Equal([]map[string]any{
+			{"level": "WARN", "msg": "m1", "a": "1", "g": map[string]any{"b": "2", "c": "3", "logger": "n1.n2"}},
+			{"level": "DEBUG", "msg": "m2", "a": "1", "g": map[string]any{"b": "2", "logger": "n1.n2"}},
+		}))
+	})
+
+	t.Run("Caller", func(t Test) {
+		records, logger := setup(t, zap.AddCaller(), zap.AddStacktrace(zapcore.DPanicLevel))
+
+		logger.Error("m1")
+		logger.DPanic("m2")
+
+		result := records()
+		t.Expect(result).To(HaveLen(2))
+		t.Expect(result[0]).To(Equal(map[string]any{
+			"level":  "ERROR",
+			"msg":    "m1",
+			"source": "github.com/pamburus/slogx/slogxzap_test.TestCore.func4",
+		}))
+		t.Expect(result[1]["level"]).To(Equal("CRITICAL"))
+		t.Expect(result[1]["source"]).To(Equal("github.com/pamburus/slogx/slogxzap_test.TestCore.func4"))
+		t.Expect(result[1][slogx.StackKey]).ToNot(BeNil())
+	})
+
+	t.Run("Enabled", func(t Test) {
+
+		handler := slog.NewJSONHandler(&bytes.Buffer{}, &slog.HandlerOptions{Level: slog.LevelWarn})
+		core := slogxzap.NewCore(handler)
+
+		t.Expect(core.Enabled(zapcore.InfoLevel)).To(BeFalse())
+		t.Expect(core.Enabled(zapcore.WarnLevel)).To(BeTrue())
+		t.Expect(core.Sync()).ToNot(HaveOccurred())
+	})
+}
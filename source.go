@@ -0,0 +1,219 @@
+package slogx
+
+import (
+	"log/slog"
+	"os"
+	"path"
+	"runtime"
+	"runtime/debug"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// SourceOptions specifies how a handler built using [TweakHandlerBuilder.WithSourceOptions] renders the source location.
+type SourceOptions struct {
+	// RelativePaths specifies whether to render file paths relative to the root directory of the main module.
+	// Files of other modules are rendered relative to the module cache, prefixed with their module path without version,
+	// and files of the standard library are rendered prefixed with their package path.
+	RelativePaths bool
+	// OmitFunction specifies whether to omit the function name.
+	OmitFunction bool
+}
+
+// ---
+
+func (o SourceOptions) attr(pc uintptr) slog.Attr {
+	frame, _ := runtime.CallersFrames([]uintptr{pc}).Next()
+
+	source := &slog.Source{
+		Function: frame.Function,
+		File:     frame.File,
+		Line:     frame.Line,
+	}
+
+	if o.RelativePaths {
+		source.File = relativePath(frame.Function, frame.File)
+	}
+
+	if o.OmitFunction {
+		source.Function = ""
+	}
+
+	return slog.Any(slog.SourceKey, source)
+}
+
+// ---
+
+// relativePath returns the path of the file relative to the root of the main module if the file belongs to it,
+// or the path of the file prefixed with the path of its module or package otherwise.
+func relativePath(function, file string) string {
+	if root := mainModuleRoot(function, file); root != "" && strings.HasPrefix(file, root+"/") {
+		return file[len(root)+1:]
+	}
+
+	pkg := packagePath(function)
+	if pkg != "" && !strings.Contains(firstElement(pkg), ".") {
+		// Standard library and other packages without a domain name in their paths.
+		return path.Join(pkg, path.Base(file))
+	}
+
+	if i := strings.LastIndex(file, modCacheDir); i >= 0 {
+		if result, ok := modCachePath(file[i+len(modCacheDir):]); ok {
+			return result
+		}
+	}
+
+	if pkg == "" {
+		return file
+	}
+
+	return path.Join(pkg, path.Base(file))
+}
+
+// mainModuleRoot returns the directory of the main module.
+// The directory is discovered from the first file found to belong to a package of the main module
+// by removing the package path relative to the module path from the directory of the file.
+func mainModuleRoot(function, file string) string {
+	if root := moduleRoot.Load(); root != nil {
+		return *root
+	}
+
+	pkg := packagePath(function)
+	module := ""
+
+	if info := mainBuildInfo(); info != nil {
+		module = info.Main.Path
+		if pkg == "main" {
+			pkg = info.Path
+		}
+	}
+
+	dir := path.Dir(file)
+
+	if module == "" && !strings.Contains(file, modCacheDir) {
+		// Test binaries built by Go before 1.24 have no main module in their build information,
+		// so the module is taken from the go.mod file found in the directory of the file or its parents.
+		module = modulePath(dir)
+	}
+
+	if module == "" || (pkg != module && !strings.HasPrefix(pkg, module+"/")) {
+		return ""
+	}
+
+	if !strings.HasSuffix(dir, pkg[len(module):]) {
+		return ""
+	}
+
+	root := dir[:len(dir)-len(pkg)+len(module)]
+	moduleRoot.CompareAndSwap(nil, &root)
+
+	return *moduleRoot.Load()
+}
+
+// modulePath returns the module path declared in the go.mod file found in the directory or its parents.
+// The results are cached per directory, so the files are read only once.
+func modulePath(dir string) string {
+	if module, ok := modulePaths.Load(dir); ok {
+		return module.(string)
+	}
+
+	module := ""
+
+	data, err := os.ReadFile(path.Join(dir, "go.mod"))
+	if err == nil {
+		module = moduleDirective(data)
+	} else if parent := path.Dir(dir); parent != dir {
+		module = modulePath(parent)
+	}
+
+	modulePaths.Store(dir, module)
+
+	return module
+}
+
+// moduleDirective returns the module path from the module directive of the go.mod file contents.
+func moduleDirective(data []byte) string {
+	for _, line := range strings.Split(string(data), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) >= 2 && fields[0] == "module" {
+			return strings.Trim(fields[1], "\"`")
+		}
+	}
+
+	return ""
+}
+
+// modCachePath converts a path of a file relative to the module cache directory,
+// like "gopkg.in/yaml.v3@v3.0.1/decode.go", to the path prefixed with the package path,
+// like "gopkg.in/yaml.v3/decode.go".
+func modCachePath(file string) (string, bool) {
+	at := strings.IndexByte(file, '@')
+	if at < 0 {
+		return "", false
+	}
+
+	end := strings.IndexByte(file[at:], '/')
+	if end < 0 {
+		return "", false
+	}
+
+	return unescapeModulePath(file[:at]) + file[at+end:], true
+}
+
+// unescapeModulePath reverses the case encoding of module paths in the module cache,
+// where each upper-case letter is replaced by an exclamation mark followed by the corresponding lower-case letter.
+func unescapeModulePath(escaped string) string {
+	if !strings.Contains(escaped, "!") {
+		return escaped
+	}
+
+	var b strings.Builder
+
+	for i := 0; i < len(escaped); i++ {
+		c := escaped[i]
+		if c == '!' && i+1 < len(escaped) {
+			i++
+			c = escaped[i] - 'a' + 'A'
+		}
+
+		b.WriteByte(c)
+	}
+
+	return b.String()
+}
+
+// packagePath returns the path of the package of the function with the given fully qualified name.
+// Dots in the last element of the package path are escaped as %2e in function names, so they are unescaped.
+func packagePath(function string) string {
+	slash := strings.LastIndexByte(function, '/')
+
+	dot := strings.IndexByte(function[slash+1:], '.')
+	if dot < 0 {
+		return ""
+	}
+
+	return strings.TrimSuffix(strings.ReplaceAll(function[:slash+1+dot], "%2e", "."), "_test")
+}
+
+func firstElement(pkg string) string {
+	if i := strings.IndexByte(pkg, '/'); i >= 0 {
+		return pkg[:i]
+	}
+
+	return pkg
+}
+
+// ---
+
+const modCacheDir = "/pkg/mod/"
+
+var (
+	moduleRoot    atomic.Pointer[string]
+	modulePaths   sync.Map
+	mainBuildInfo = sync.OnceValue(func() *debug.BuildInfo {
+		info, _ := debug.ReadBuildInfo()
+
+		return info
+	})
+)
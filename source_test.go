@@ -0,0 +1,140 @@
+package slogx_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"runtime"
+	"strings"
+	"testing"
+
+	. "github.com/pamburus/go-tst/tst"
+	"github.com/pamburus/slogx"
+	"github.com/pamburus/slogx/internal/mock"
+)
+
+func TestSourceLevel(tt *testing.T) {
+	t := New(tt)
+
+	cl := mock.NewCallLog()
+	logger := slogx.New(mock.NewHandler(cl)).WithSourceLevel(slog.LevelWarn)
+
+	pcs := func() []uintptr {
+		var result []uintptr
+
+		for _, call := range cl.Calls() {
+			if call, ok := call.(mock.HandlerHandle); ok {
+				result = append(result, call.Record.PC)
+			}
+		}
+
+		return result
+	}
+
+	logger.Info("m1")
+	logger.Warn("m2")
+	logger.ContextLogger().Error(context.Background(), "m3")
+	logger.WithSource(true).Info("m4")
+	logger.WithSource(false).Error("m5")
+
+	result := pcs()
+	t.Expect(result).To(HaveLen(5))
+	t.Expect(result[0]).To(Equal(uintptr(0)))
+	t.Expect(functionOf(result[1])).To(Equal("github.com/pamburus/slogx_test.TestSourceLevel"))
+	t.Expect(functionOf(result[2])).To(Equal("github.com/pamburus/slogx_test.TestSourceLevel"))
+	t.Expect(functionOf(result[3])).To(Equal("github.com/pamburus/slogx_test.TestSourceLevel"))
+	t.Expect(result[4]).To(Equal(uintptr(0)))
+}
+
+func TestSourceOptions(tt *testing.T) {
+	t := New(tt)
+
+	render := func(t Test, options slogx.SourceOptions) any {
+		var buf bytes.Buffer
+
+		handler := slogx.TweakHandler(slog.NewJSONHandler(&buf, &slog.HandlerOptions{AddSource: true})).
+			WithSourceOptions(options).
+			Result()
+
+		slogx.New(handler).Info("m1")
+
+		var result map[string]any
+		t.Expect(json.Unmarshal(buf.Bytes(), &result)).ToNot(HaveOccurred())
+
+		source := result[slog.SourceKey].(map[string]any)
+		t.Expect(source["line"]).To(BeGreaterThan(float64(0)))
+		delete(source, "line")
+
+		return source
+	}
+
+	t.Run("Relative", func(t Test) {
+		t.Expect(render(t, slogx.SourceOptions{RelativePaths: true})).To(Equal(map[string]any{
+			"function": "github.com/pamburus/slogx_test.TestSourceOptions.func1",
+			"file":     "source_test.go",
+		}))
+	})
+
+	t.Run("OmitFunction", func(t Test) {
+		t.Expect(render(t, slogx.SourceOptions{RelativePaths: true, OmitFunction: true})).To(Equal(map[string]any{
+			"file": "source_test.go",
+		}))
+	})
+
+	t.Run("Absolute", func(t Test) {
+		source := render(t, slogx.SourceOptions{OmitFunction: true}).(map[string]any)
+		t.Expect(source).To(HaveLen(1))
+		t.Expect(source["file"]).ToNot(Equal("source_test.go"))
+	})
+
+	t.Run("TopLevel", func(t Test) {
+		var buf bytes.Buffer
+
+		handler := slogx.TweakHandler(slog.NewJSONHandler(&buf, nil)).
+			WithSourceOptions(slogx.SourceOptions{RelativePaths: true, OmitFunction: true}).
+			Result()
+
+		slogx.New(handler).WithGroup("g1").With(slog.Int("a", 1)).Info("m1", slog.Int("b", 2))
+
+		var result map[string]any
+		t.Expect(json.Unmarshal(buf.Bytes(), &result)).ToNot(HaveOccurred())
+		t.Expect(result[slog.SourceKey].(map[string]any)["file"]).To(Equal("source_test.go"))
+		t.Expect(result["g1"]).To(Equal(map[string]any{"a": float64(1), "b": float64(2)}))
+	})
+
+	t.Run("Dependencies", func(t Test) {
+		var pcs [16]uintptr
+		n := runtime.Callers(1, pcs[:])
+
+		files := make(map[string]string)
+
+		for _, pc := range pcs[:n] {
+			var buf bytes.Buffer
+
+			handler := slogx.TweakHandler(slog.NewJSONHandler(&buf, nil)).
+				WithSourceOptions(slogx.SourceOptions{RelativePaths: true}).
+				Result()
+
+			slogx.New(handler).LogWithPC(context.Background(), pc, slog.LevelInfo, "m1")
+
+			var result map[string]any
+			t.Expect(json.Unmarshal(buf.Bytes(), &result)).ToNot(HaveOccurred())
+
+			source := result[slog.SourceKey].(map[string]any)
+			files[packageOf(source["function"].(string))] = source["file"].(string)
+		}
+
+		t.Expect(files["testing"]).To(Equal("testing/testing.go"))
+		t.Expect(strings.HasPrefix(files["github.com/pamburus/go-tst/tst"], "github.com/pamburus/go-tst/tst/")).To(BeTrue())
+		t.Expect(files["github.com/pamburus/slogx_test"]).To(Equal("source_test.go"))
+	})
+}
+
+// ---
+
+func packageOf(function string) string {
+	slash := strings.LastIndexByte(function, '/')
+
+	return function[:slash+1+strings.IndexByte(function[slash+1:], '.')]
+}
@@ -0,0 +1,157 @@
+package slogx
+
+import (
+	"context"
+	"log/slog"
+	"sync/atomic"
+)
+
+// NewSwapHandler returns a new [SwapHandler] initially delegating to the given handler.
+func NewSwapHandler(handler slog.Handler) *SwapHandler {
+	h := &SwapHandler{}
+	h.state.Store(&swapState{handler: handler})
+
+	return h
+}
+
+// ---
+
+// SwapHandler is a handler delegating to another handler that can be atomically replaced at runtime,
+// for example when the logging configuration is reloaded.
+//
+// Handlers derived from it using [slog.Handler.WithAttrs] and [slog.Handler.WithGroup],
+// including the ones captured by [Logger.LongTerm] and [Logger.WithGroup],
+// remember the chain of calls and lazily replay it onto the new handler
+// the first time they are used after the replacement.
+// The replayed handlers are cached until the next replacement.
+type SwapHandler struct {
+	state atomic.Pointer[swapState]
+}
+
+// Handler returns the current handler.
+func (h *SwapHandler) Handler() slog.Handler {
+	return h.state.Load().handler
+}
+
+// Swap atomically replaces the current handler and returns the previous one.
+// It is the caller's responsibility to flush or close the previous handler when needed,
+// see [Flush] and [Close].
+func (h *SwapHandler) Swap(handler slog.Handler) slog.Handler {
+	for {
+		old := h.state.Load()
+		if h.state.CompareAndSwap(old, &swapState{handler, old.generation + 1}) {
+			return old.handler
+		}
+	}
+}
+
+// Enabled reports whether the current handler handles records at the given level.
+func (h *SwapHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.Handler().Enabled(ctx, level)
+}
+
+// Handle handles the record using the current handler.
+func (h *SwapHandler) Handle(ctx context.Context, record slog.Record) error {
+	return h.Handler().Handle(ctx, record)
+}
+
+// WithAttrs returns a new handler with the given attributes that follows replacements of the current handler.
+func (h *SwapHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+
+	return &swapChild{root: h, attrs: attrs}
+}
+
+// WithGroup returns a new handler with the given group that follows replacements of the current handler.
+func (h *SwapHandler) WithGroup(key string) slog.Handler {
+	if key == "" {
+		return h
+	}
+
+	return &swapChild{root: h, group: key}
+}
+
+// Flush flushes the current handler, see [Flusher].
+func (h *SwapHandler) Flush(ctx context.Context) error {
+	return Flush(ctx, h.Handler())
+}
+
+// Close closes the current handler, see [Closer].
+func (h *SwapHandler) Close(ctx context.Context) error {
+	return Close(ctx, h.Handler())
+}
+
+// ---
+
+type swapState struct {
+	handler    slog.Handler
+	generation uint64
+}
+
+// ---
+
+type swapChild struct {
+	root   *SwapHandler
+	parent *swapChild
+	attrs  []slog.Attr
+	group  string
+	cache  atomic.Pointer[swapState]
+}
+
+func (h *swapChild) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.resolve(h.root.state.Load()).Enabled(ctx, level)
+}
+
+func (h *swapChild) Handle(ctx context.Context, record slog.Record) error {
+	return h.resolve(h.root.state.Load()).Handle(ctx, record)
+}
+
+func (h *swapChild) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+
+	return &swapChild{root: h.root, parent: h, attrs: attrs}
+}
+
+func (h *swapChild) WithGroup(key string) slog.Handler {
+	if key == "" {
+		return h
+	}
+
+	return &swapChild{root: h.root, parent: h, group: key}
+}
+
+func (h *swapChild) Flush(ctx context.Context) error {
+	return h.root.Flush(ctx)
+}
+
+func (h *swapChild) Close(ctx context.Context) error {
+	return h.root.Close(ctx)
+}
+
+// resolve returns the handler derived from the handler of the given state by replaying the chain of calls.
+// Concurrent callers may replay the chain simultaneously, which is harmless because the results are equivalent.
+func (h *swapChild) resolve(state *swapState) slog.Handler {
+	if cached := h.cache.Load(); cached != nil && cached.generation == state.generation {
+		return cached.handler
+	}
+
+	base := state.handler
+	if h.parent != nil {
+		base = h.parent.resolve(state)
+	}
+
+	var handler slog.Handler
+	if h.group != "" {
+		handler = base.WithGroup(h.group)
+	} else {
+		handler = base.WithAttrs(h.attrs)
+	}
+
+	h.cache.Store(&swapState{handler, state.generation})
+
+	return handler
+}
@@ -0,0 +1,119 @@
+package slogx_test
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"sync"
+	"testing"
+
+	. "github.com/pamburus/go-tst/tst"
+	"github.com/pamburus/slogx"
+	"github.com/pamburus/slogx/internal/mock"
+)
+
+func TestSwapHandler(tt *testing.T) {
+	t := New(tt)
+	ctx := context.Background()
+
+	newHandler := func(buf *bytes.Buffer) slog.Handler {
+		return slog.NewTextHandler(buf, &slog.HandlerOptions{
+			ReplaceAttr: func(groups []string, attr slog.Attr) slog.Attr {
+				if len(groups) == 0 && attr.Key == slog.TimeKey {
+					return slog.Attr{}
+				}
+
+				return attr
+			},
+		})
+	}
+
+	t.Run("Replay", func(t Test) {
+		var buf1, buf2 bytes.Buffer
+
+		handler := slogx.NewSwapHandler(newHandler(&buf1))
+		logger := slogx.New(handler).WithSource(false).WithLongTerm(slog.Int("a", 1)).WithGroup("g").With(slog.Int("b", 2))
+
+		logger.Info("m1")
+		t.Expect(handler.Swap(newHandler(&buf2))).ToNot(BeNil())
+		logger.Info("m2")
+		logger.WithGroup("h").Info("m3", slog.Int("c", 3))
+
+		t.Expect(buf1.String()).To(Equal("level=INFO msg=m1 a=1 g.b=2\n"))
+		t.Expect(buf2.String()).To(Equal("level=INFO msg=m2 a=1 g.b=2\nlevel=INFO msg=m3 a=1 g.b=2 g.h.c=3\n"))
+	})
+
+	t.Run("Lazy", func(t Test) {
+		cl1 := mock.NewCallLog()
+		cl2 := mock.NewCallLog()
+
+		handler := slogx.NewSwapHandler(mock.NewHandler(cl1))
+		derived := handler.WithAttrs([]slog.Attr{slog.Int("a", 1)}).WithGroup("g")
+
+		t.Expect(derived.Enabled(ctx, slog.LevelInfo)).To(BeTrue())
+		t.Expect(cl1.Calls()).To(HaveLen(3))
+
+		handler.Swap(mock.NewHandler(cl2))
+		t.Expect(cl2.Calls()).To(HaveLen(0))
+
+		t.Expect(derived.Enabled(ctx, slog.LevelInfo)).To(BeTrue())
+		t.Expect(cl2.Calls()).To(Equal(mock.CallList{
+			mock.HandlerWithAttrs{Instance: "0", Attrs: []mock.Attr{{Key: "a", Value: int64(1)}}},
+			mock.HandlerWithGroup{Instance: "0.1", Key: "g"},
+			mock.HandlerEnabled{Instance: "0.1.2", Level: slog.LevelInfo},
+		}))
+		t.Expect(cl1.Calls()).To(HaveLen(3))
+	})
+
+	t.Run("Empty", func(t Test) {
+		handler := slogx.NewSwapHandler(slogx.Discard())
+		derived := handler.WithAttrs([]slog.Attr{slog.Int("a", 1)})
+
+		t.Expect(handler.WithAttrs(nil)).To(Equal(slog.Handler(handler)))
+		t.Expect(handler.WithGroup("")).To(Equal(slog.Handler(handler)))
+		t.Expect(derived.WithAttrs(nil)).To(Equal(derived))
+		t.Expect(derived.WithGroup("")).To(Equal(derived))
+	})
+
+	t.Run("Flush", func(t Test) {
+		var log []string
+
+		handler := slogx.NewSwapHandler(&flushingHandler{"h1", &log, nil})
+		derived := handler.WithGroup("g")
+
+		t.Expect(slogx.Flush(ctx, derived)).ToNot(HaveOccurred())
+		previous := handler.Swap(&flushingHandler{"h2", &log, nil})
+		t.Expect(slogx.Close(ctx, previous)).ToNot(HaveOccurred())
+		t.Expect(slogx.Close(ctx, derived)).ToNot(HaveOccurred())
+
+		t.Expect(log).To(Equal([]string{"flush h1", "close h1", "close h2"}))
+	})
+
+	t.Run("Concurrent", func(t Test) {
+		var buf bytes.Buffer
+
+		handler := slogx.NewSwapHandler(slogx.Discard())
+		logger := slogx.New(handler).WithSource(false).WithLongTerm(slog.Int("a", 1))
+
+		var wg sync.WaitGroup
+
+		for range 4 {
+			wg.Add(1)
+
+			go func() {
+				defer wg.Done()
+
+				for range 100 {
+					logger.Info("m")
+					handler.Swap(slogx.Discard())
+				}
+			}()
+		}
+
+		wg.Wait()
+
+		handler.Swap(newHandler(&buf))
+		logger.Info("m")
+		t.Expect(buf.String()).To(Equal("level=INFO msg=m a=1\n"))
+	})
+}
@@ -0,0 +1,174 @@
+package slogx
+
+import (
+	"cmp"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ByteSizeAttr returns an attribute with the size in bytes rendered using binary units, for example "1.5 MiB".
+// See [ByteSize] for details.
+// Sizes above 255 bytes take a single allocation to store the size in the value.
+func ByteSizeAttr(key string, size int64) slog.Attr {
+	return slog.Any(key, ByteSize(size))
+}
+
+// DurationMsAttr returns an attribute with the duration rendered as a floating point number of milliseconds.
+func DurationMsAttr(key string, d time.Duration) slog.Attr {
+	return slog.Float64(key, float64(d)/float64(time.Millisecond))
+}
+
+// HexAttr returns an attribute with the bytes rendered as a hexadecimal string.
+// The encoding is deferred until the handler resolves the value,
+// so only a single allocation to store the slice header is made if the value is never resolved.
+// The slice is not copied, so it must not be modified until the record is handled.
+func HexAttr(key string, data []byte) slog.Attr {
+	return slog.Any(key, hexValue(data))
+}
+
+// Base64Attr returns an attribute with the bytes rendered as a standard base64 string.
+// The encoding is deferred until the handler resolves the value,
+// so only a single allocation to store the slice header is made if the value is never resolved.
+// The slice is not copied, so it must not be modified until the record is handled.
+func Base64Attr(key string, data []byte) slog.Attr {
+	return slog.Any(key, base64Value(data))
+}
+
+// StringerAttr returns an attribute with the value rendered using its String method.
+// The String method is not called until the handler resolves the value.
+// Nil values, including nil pointers, are rendered as "<nil>".
+// It takes a single allocation to wrap the interface value.
+func StringerAttr(key string, value fmt.Stringer) slog.Attr {
+	return slog.Any(key, stringerValue{value})
+}
+
+// PtrAttr returns an attribute with the value the pointer points to, or a nil value if the pointer is nil.
+// The pointer is dereferenced when the handler resolves the value, so it does not allocate,
+// but the value must not be modified until the record is handled.
+func PtrAttr[T any](key string, value *T) slog.Attr {
+	if value == nil {
+		return slog.Any(key, nil)
+	}
+
+	return slog.Any(key, ptrValue[T]{value})
+}
+
+// MapAttr returns an attribute with the map rendered as a group, having a member for each key in sorted order.
+// The map is not copied, so it must not be modified until the record is handled.
+// Nothing is allocated until the handler resolves the value.
+func MapAttr[K cmp.Ordered, V any](key string, m map[K]V) slog.Attr {
+	return slog.Any(key, mapValue[K, V](m))
+}
+
+// ---
+
+// ByteSize is a size in bytes.
+// It is rendered using binary units with up to two decimal places, for example "512 B", "1 KiB" or "1.5 MiB".
+type ByteSize int64
+
+// String returns the size rendered using binary units.
+func (s ByteSize) String() string {
+	const unit = 1024
+
+	size := int64(s)
+	if size > -unit && size < unit {
+		return strconv.FormatInt(size, 10) + " B"
+	}
+
+	value := float64(size)
+	i := -1
+
+	for value <= -unit || value >= unit {
+		value /= unit
+		i++
+
+		if i == len(byteSizeUnits)-1 {
+			break
+		}
+	}
+
+	text := strings.TrimRight(strings.TrimRight(strconv.FormatFloat(value, 'f', 2, 64), "0"), ".")
+
+	return text + " " + byteSizeUnits[i]
+}
+
+// LogValue implements [slog.LogValuer].
+func (s ByteSize) LogValue() slog.Value {
+	return slog.StringValue(s.String())
+}
+
+var byteSizeUnits = [...]string{"KiB", "MiB", "GiB", "TiB", "PiB", "EiB"}
+
+// ---
+
+// ptrValue is a single pointer, so it is stored in an interface without allocation.
+type ptrValue[T any] struct {
+	ptr *T
+}
+
+func (v ptrValue[T]) LogValue() slog.Value {
+	return slog.AnyValue(*v.ptr)
+}
+
+// ---
+
+type hexValue []byte
+
+func (v hexValue) LogValue() slog.Value {
+	return slog.StringValue(hex.EncodeToString(v))
+}
+
+// ---
+
+type base64Value []byte
+
+func (v base64Value) LogValue() slog.Value {
+	return slog.StringValue(base64.StdEncoding.EncodeToString(v))
+}
+
+// ---
+
+type stringerValue struct {
+	value fmt.Stringer
+}
+
+func (v stringerValue) LogValue() slog.Value {
+	if v.value == nil {
+		return slog.StringValue(nilString)
+	}
+
+	if rv := reflect.ValueOf(v.value); rv.Kind() == reflect.Pointer && rv.IsNil() {
+		return slog.StringValue(nilString)
+	}
+
+	return slog.StringValue(v.value.String())
+}
+
+const nilString = "<nil>"
+
+// ---
+
+type mapValue[K cmp.Ordered, V any] map[K]V
+
+func (v mapValue[K, V]) LogValue() slog.Value {
+	keys := make([]K, 0, len(v))
+	for key := range v {
+		keys = append(keys, key)
+	}
+
+	slices.Sort(keys)
+
+	attrs := make([]slog.Attr, len(keys))
+	for i, key := range keys {
+		attrs[i] = slog.Any(fmt.Sprint(key), v[key])
+	}
+
+	return slog.GroupValue(attrs...)
+}
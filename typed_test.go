@@ -0,0 +1,120 @@
+package slogx_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/netip"
+	"testing"
+	"time"
+
+	. "github.com/pamburus/go-tst/tst"
+	"github.com/pamburus/slogx"
+)
+
+func TestTypedAttrs(tt *testing.T) {
+	t := New(tt)
+
+	render := func(t Test, attr slog.Attr) any {
+		var buf bytes.Buffer
+
+		slogx.New(slog.NewJSONHandler(&buf, nil)).WithSource(false).Info("msg", attr)
+
+		var result map[string]any
+		t.Expect(json.Unmarshal(buf.Bytes(), &result)).ToNot(HaveOccurred())
+
+		return result["a"]
+	}
+
+	t.Run("ByteSize", func(t Test) {
+		for _, tc := range []struct {
+			size int64
+			text string
+		}{
+			{0, "0 B"},
+			{1023, "1023 B"},
+			{-1023, "-1023 B"},
+			{1024, "1 KiB"},
+			{1536, "1.5 KiB"},
+			{1024*1024 + 1, "1 MiB"},
+			{5 * 1024 * 1024 * 1024 / 3, "1.67 GiB"},
+			{-2 * 1024 * 1024 * 1024 * 1024, "-2 TiB"},
+			{1 << 62, "4 EiB"},
+		} {
+			t.Expect(slogx.ByteSize(tc.size).String()).To(Equal(tc.text))
+		}
+
+		t.Expect(render(t, slogx.ByteSizeAttr("a", 1536))).To(Equal("1.5 KiB"))
+	})
+
+	t.Run("DurationMs", func(t Test) {
+		attr := slogx.DurationMsAttr("a", 1500*time.Microsecond)
+		t.Expect(attr.Value.Kind()).To(Equal(slog.KindFloat64))
+		t.Expect(render(t, attr)).To(Equal(1.5))
+	})
+
+	t.Run("Bytes", func(t Test) {
+		t.Expect(render(t, slogx.HexAttr("a", []byte{0xca, 0xfe}))).To(Equal("cafe"))
+		t.Expect(render(t, slogx.Base64Attr("a", []byte("hello")))).To(Equal("aGVsbG8="))
+	})
+
+	t.Run("Stringer", func(t Test) {
+		var nilAddr *netip.Addr
+
+		t.Expect(render(t, slogx.StringerAttr("a", netip.MustParseAddr("10.0.0.1")))).To(Equal("10.0.0.1"))
+		t.Expect(render(t, slogx.StringerAttr("a", nil))).To(Equal("<nil>"))
+		t.Expect(render(t, slogx.StringerAttr("a", nilAddr))).To(Equal("<nil>"))
+	})
+
+	t.Run("Ptr", func(t Test) {
+		value := 42
+
+		t.Expect(render(t, slogx.PtrAttr("a", &value))).To(Equal(float64(42)))
+		t.Expect(render(t, slogx.PtrAttr[int]("a", nil))).To(BeNil())
+	})
+
+	t.Run("Map", func(t Test) {
+		attr := slogx.MapAttr("a", map[int]string{3: "c", 1: "a", 2: "b"})
+		t.Expect(attr.Value.Resolve().Group()).To(Equal([]slog.Attr{
+			slog.String("1", "a"),
+			slog.String("2", "b"),
+			slog.String("3", "c"),
+		}))
+		t.Expect(render(t, attr)).To(Equal(map[string]any{"1": "a", "2": "b", "3": "c"}))
+	})
+
+	t.Run("Allocs", func(t Test) {
+		data := []byte{0xca, 0xfe}
+		size := int64(768 * len(data))
+		value := 42
+		m := map[string]int{"x": 1}
+		addr := netip.IPv4Unspecified()
+
+		for _, tc := range []struct {
+			name   string
+			attr   func() slog.Attr
+			allocs float64
+		}{
+			{"DurationMs", func() slog.Attr { return slogx.DurationMsAttr("a", time.Second) }, 0},
+			{"Ptr", func() slog.Attr { return slogx.PtrAttr("a", &value) }, 0},
+			{"NilPtr", func() slog.Attr { return slogx.PtrAttr[int]("a", nil) }, 0},
+			{"Map", func() slog.Attr { return slogx.MapAttr("a", m) }, 0},
+			{"SmallByteSize", func() slog.Attr { return slogx.ByteSizeAttr("a", 255) }, 0},
+			{"ByteSize", func() slog.Attr { return slogx.ByteSizeAttr("a", size) }, 1},
+			{"Hex", func() slog.Attr { return slogx.HexAttr("a", data) }, 1},
+			{"Base64", func() slog.Attr { return slogx.Base64Attr("a", data) }, 1},
+			{"Stringer", func() slog.Attr { return slogx.StringerAttr("a", &addr) }, 1},
+		} {
+			t.Run(tc.name, func(t Test) {
+				allocs := testing.AllocsPerRun(100, func() {
+					attrSink = tc.attr()
+				})
+				t.Expect(allocs).To(Equal(tc.allocs))
+			})
+		}
+	})
+}
+
+// ---
+
+var attrSink slog.Attr